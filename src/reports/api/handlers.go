@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleCredits24h(w http.ResponseWriter, r *http.Request) {
+	credits, err := s.manager.GetRecentCredits24Hours(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, credits)
+}
+
+func (s *Server) handleCreditsHistory(w http.ResponseWriter, r *http.Request) {
+	credits, err := s.manager.GetRecentCredits(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, credits)
+}
+
+func (s *Server) handleFX(w http.ResponseWriter, r *http.Request) {
+	base := r.URL.Query().Get("base")
+	quote := r.URL.Query().Get("quote")
+	if base == "" || quote == "" {
+		writeError(w, http.StatusBadRequest, "base and quote query parameters are required")
+		return
+	}
+
+	rate, asOf, err := s.manager.FX.Rate(r.Context(), base, quote)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"base":  base,
+		"quote": quote,
+		"rate":  rate,
+		"as_of": asOf,
+	})
+}
+
+type reportSendRequest struct {
+	Method string `json:"method"`
+}
+
+func (s *Server) handleReportsSend(w http.ResponseWriter, r *http.Request) {
+	var req reportSendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Method == "" {
+		writeError(w, http.StatusBadRequest, "method is required")
+		return
+	}
+
+	if err := s.manager.CreateReportAndSend(r.Context(), req.Method); err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "sent"})
+}
+
+type taxReportSendRequest struct {
+	From     string `json:"from"` // "2006-01-02"
+	To       string `json:"to"`   // "2006-01-02"
+	Currency string `json:"currency"`
+}
+
+func (s *Server) handleTaxReportSend(w http.ResponseWriter, r *http.Request) {
+	var req taxReportSendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Currency == "" {
+		writeError(w, http.StatusBadRequest, "currency is required")
+		return
+	}
+	from, err := time.Parse("2006-01-02", req.From)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "from must be a date in the form 2006-01-02: "+err.Error())
+		return
+	}
+	to, err := time.Parse("2006-01-02", req.To)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "to must be a date in the form 2006-01-02: "+err.Error())
+		return
+	}
+
+	resp, err := s.manager.SendTaxReportEmail(r.Context(), from, to, req.Currency)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	if resp < 200 || resp >= 300 {
+		writeError(w, http.StatusBadGateway, "unacceptable return code from mail provider")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "sent"})
+}
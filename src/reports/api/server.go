@@ -0,0 +1,70 @@
+// Package api exposes a Manager over HTTP, so a farm's dashboard or
+// on-call tooling can poll credits and trigger report sends instead of
+// invoking this module as a one-shot CLI.
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports"
+)
+
+// Server exposes a reports.Manager over HTTP.
+type Server struct {
+	manager *reports.Manager
+	token   string
+	router  chi.Router
+}
+
+// New constructs a Server backed by manager. Every route except
+// /healthz requires an "Authorization: Bearer <token>" header matching
+// token.
+func New(manager *reports.Manager, token string) *Server {
+	s := &Server{manager: manager, token: token}
+
+	r := chi.NewRouter()
+	r.Get("/healthz", s.handleHealthz)
+
+	r.Group(func(r chi.Router) {
+		r.Use(s.authenticate)
+		r.Get("/credits/24h", s.handleCredits24h)
+		r.Get("/credits/history", s.handleCreditsHistory)
+		r.Get("/fx", s.handleFX)
+		r.Post("/reports/send", s.handleReportsSend)
+		r.Post("/reports/tax/send", s.handleTaxReportSend)
+	})
+
+	s.router = r
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+// ListenAndServe starts the server on addr.
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s)
+}
+
+// authenticate rejects requests missing a bearer token matching
+// s.token. A Server constructed with an empty token accepts every
+// request, matching how GenerateReportManagerFromFile leaves
+// subsystems like Store disabled when unconfigured.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/fx"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/mailer"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/pool"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/templates"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/types"
+)
+
+type stubPool struct{}
+
+func (stubPool) RecentCredits24h(ctx context.Context, coin string) (pool.Credits, error) {
+	return types.RecentCredits{Amount: 1.5, Timestamp: 1000}, nil
+}
+
+func (stubPool) RecentCreditsHistory(ctx context.Context, coin string) ([]pool.Credits, error) {
+	return []pool.Credits{{Amount: 1.5, Timestamp: 1000}}, nil
+}
+
+func newTestManager() *reports.Manager {
+	renderer, err := templates.New(filepath.Join("..", "templates", "html"))
+	if err != nil {
+		panic(err)
+	}
+	return &reports.Manager{
+		Config:    &config.Config{Coin: "ETH", TargetCurrencies: []string{"USD"}},
+		Pool:      stubPool{},
+		FX:        fx.NewFixedProvider(map[string]float64{"ETH_USD": 1800}),
+		Mailer:    mailer.NewMockMailer(),
+		Templates: renderer,
+	}
+}
+
+func TestHealthzIsUnauthenticated(t *testing.T) {
+	s := New(newTestManager(), "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %v", rec.Code)
+	}
+}
+
+func TestProtectedRouteRequiresBearerToken(t *testing.T) {
+	s := New(newTestManager(), "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/credits/24h", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 without a token, got %v", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/credits/24h", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200 with a valid token, got %v: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleReportsSend(t *testing.T) {
+	manager := newTestManager()
+	s := New(manager, "")
+
+	body := strings.NewReader(`{"method":"24hour_credit"}`)
+	req := httptest.NewRequest(http.MethodPost, "/reports/send", body)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %v: %s", rec.Code, rec.Body.String())
+	}
+	if got := len(manager.Mailer.(*mailer.MockMailer).Sent); got != 1 {
+		t.Fatalf("expected 1 email sent, got %d", got)
+	}
+}
+
+func TestHandleReportsSendRejectsMissingMethod(t *testing.T) {
+	s := New(newTestManager(), "")
+
+	req := httptest.NewRequest(http.MethodPost, "/reports/send", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %v: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleFX(t *testing.T) {
+	s := New(newTestManager(), "")
+
+	req := httptest.NewRequest(http.MethodGet, "/fx?base=ETH&quote=USD", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %v: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// Config holds all of the settings needed to fetch pool credits, convert
+// them to fiat, and email a report.
+type Config struct {
+	// SendgridAPIKey is the API key used to authenticate against Sendgrid.
+	SendgridAPIKey string `json:"sendgrid_api_key"`
+	// URL is the pool API URL template, formatted with Coin, an action
+	// name, and APIKey by the pool client before use.
+	URL string `json:"url"`
+	// Coin is the coin symbol to request credit information for, eg "ETH".
+	Coin string `json:"coin"`
+	// APIKey is the pool account API key.
+	APIKey string `json:"api_key"`
+
+	// PoolProvider selects the pool.Client implementation used to fetch
+	// credits, eg "miningpoolhub", "ethermine", "flexpool", or
+	// "jsonrpc". Defaults to "miningpoolhub" when empty.
+	PoolProvider string `json:"pool_provider"`
+	// Ethermine holds the settings used when PoolProvider is "ethermine".
+	Ethermine EthermineConfig `json:"ethermine"`
+	// Flexpool holds the settings used when PoolProvider is "flexpool".
+	Flexpool FlexpoolConfig `json:"flexpool"`
+	// JSONRPC holds the settings used when PoolProvider is "jsonrpc".
+	JSONRPC JSONRPCConfig `json:"jsonrpc"`
+
+	// FXProvider selects the fx.Provider implementation used to price
+	// Coin against TargetCurrencies, eg "coingecko",
+	// "currencyconverter", or "fixed". Defaults to "coingecko" when
+	// empty, since it's the only provider that prices crypto directly.
+	FXProvider string `json:"fx_provider"`
+	// TargetCurrencies lists the fiat currencies a report should show
+	// side-by-side, eg []string{"USD", "CAD", "EUR", "GBP"}. Defaults
+	// to []string{"USD", "CAD"} when empty.
+	TargetCurrencies []string `json:"target_currencies"`
+	// FXCacheTTLSeconds bounds how long a looked-up rate is reused
+	// before being refreshed. Defaults to fx.DefaultCacheTTL (5m) when
+	// <= 0.
+	FXCacheTTLSeconds int `json:"fx_cache_ttl_seconds"`
+	// CoinGeckoIDs overrides or extends the coin symbol -> CoinGecko
+	// asset id mapping used when FXProvider is "coingecko".
+	CoinGeckoIDs map[string]string `json:"coingecko_ids"`
+	// FXFixedRates holds "BASE_QUOTE" -> rate entries used when
+	// FXProvider is "fixed".
+	FXFixedRates map[string]float64 `json:"fx_fixed_rates"`
+
+	// ReportDBPath is the path to the SQLite database every fetched
+	// credit sample is recorded to. Sample recording is skipped when
+	// empty.
+	ReportDBPath string `json:"report_db_path"`
+
+	// TemplateDir is the directory report email templates are loaded
+	// from. Template-rendered sends are unavailable when empty.
+	TemplateDir string `json:"template_dir"`
+
+	// APIAddr is the address for the reports/api HTTP server to listen
+	// on, eg ":8080". No package in this module reads it or starts the
+	// server; api.Server.ListenAndServe takes the address as an
+	// argument.
+	APIAddr string `json:"api_addr"`
+	// APIBearerToken is required on every reports/api request (except
+	// /healthz) via "Authorization: Bearer <token>". Authentication is
+	// disabled when empty.
+	APIBearerToken string `json:"api_bearer_token"`
+
+	// MailProvider selects the Mailer implementation used to send
+	// reports, eg "sendgrid", "smtp", "mailgun", "mandrill", or "null".
+	// Defaults to "sendgrid" when empty.
+	MailProvider string `json:"mail_provider"`
+	// SMTP holds the settings used when MailProvider is "smtp".
+	SMTP SMTPConfig `json:"smtp"`
+	// Mailgun holds the settings used when MailProvider is "mailgun".
+	Mailgun MailgunConfig `json:"mailgun"`
+	// Mandrill holds the settings used when MailProvider is "mandrill".
+	Mandrill MandrillConfig `json:"mandrill"`
+
+	// Jobs lists the recurring report jobs the reports/scheduler
+	// package drives, each on its own cron schedule. The scheduler
+	// isn't started when empty.
+	Jobs []SchedulerJob `json:"jobs"`
+	// SchedulerStatePath is the path to the JSON file reports/scheduler
+	// persists each job's last-run bookkeeping to, so a restart
+	// doesn't re-send a report that already went out this period.
+	// State isn't persisted across restarts when empty.
+	SchedulerStatePath string `json:"scheduler_state_path"`
+	// SchedulerFailureThreshold is the number of consecutive failures
+	// a job tolerates before reports/scheduler trips its circuit
+	// breaker and switches that job's sends to FallbackMailProvider.
+	// Defaults to scheduler.DefaultFailureThreshold when <= 0.
+	SchedulerFailureThreshold int `json:"scheduler_failure_threshold"`
+	// FallbackMailProvider selects the Mailer implementation
+	// reports/scheduler switches a job to once its circuit breaker
+	// trips, eg "smtp", "null". Defaults to "null" when empty.
+	FallbackMailProvider string `json:"fallback_mail_provider"`
+}
+
+// SchedulerJob is a single recurring report job driven by
+// reports/scheduler, read from Config.Jobs.
+type SchedulerJob struct {
+	// Method is the report method this job runs, passed to
+	// Manager.CreateReportAndSend, eg "24hour_credit".
+	Method string `json:"method"`
+	// CronExpr is the standard 5-field cron schedule (as parsed by
+	// robfig/cron/v3) this job runs on, eg "0 9 * * *" for daily at
+	// 9am.
+	CronExpr string `json:"cron_expr"`
+	// Recipients overrides the report's default recipient when set.
+	// Only the first address is used today, since mailer.Message
+	// carries a single "to" address; later entries are reserved for
+	// when that changes.
+	Recipients []string `json:"recipients"`
+	// Currencies overrides TargetCurrencies for this job's reports
+	// when set.
+	Currencies []string `json:"currencies"`
+}
+
+// SMTPConfig holds the settings needed to send mail via a raw SMTP
+// relay with STARTTLS.
+type SMTPConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// MailgunConfig holds the settings needed to send mail via Mailgun's
+// HTTP API.
+type MailgunConfig struct {
+	APIKey  string `json:"api_key"`
+	Domain  string `json:"domain"`
+	APIBase string `json:"api_base"`
+}
+
+// MandrillConfig holds the settings needed to send mail via Mandrill's
+// HTTP API.
+type MandrillConfig struct {
+	APIKey string `json:"api_key"`
+}
+
+// EthermineConfig holds the settings needed to query Ethermine's public
+// API for a miner's account.
+type EthermineConfig struct {
+	Address string `json:"address"`
+}
+
+// FlexpoolConfig holds the settings needed to query Flexpool's public
+// API for a miner's account.
+type FlexpoolConfig struct {
+	Address string `json:"address"`
+	Coin    string `json:"coin"`
+}
+
+// JSONRPCConfig holds the settings needed to query a generic JSON-RPC
+// mining pool endpoint.
+type JSONRPCConfig struct {
+	Endpoint string `json:"endpoint"`
+	Method   string `json:"method"`
+}
+
+// LoadConfigFromFile reads and unmarshals a Config from the JSON file at path.
+func LoadConfigFromFile(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
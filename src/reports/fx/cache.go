@@ -0,0 +1,77 @@
+package fx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultCacheTTL is the TTL applied when CachingProvider is
+// constructed with a zero ttl.
+const DefaultCacheTTL = 5 * time.Minute
+
+type cacheEntry struct {
+	rate           float64
+	asOf           time.Time
+	cacheExpiresAt time.Time
+}
+
+// CachingProvider wraps a Provider with a TTL cache and a singleflight
+// group, so repeated lookups of the same pair within the TTL window
+// hit memory instead of the network, and concurrent lookups of an
+// expired pair coalesce into a single upstream call.
+type CachingProvider struct {
+	underlying Provider
+	ttl        time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+	group   singleflight.Group
+}
+
+// NewCachingProvider wraps underlying with a cache of the given ttl. A
+// ttl <= 0 uses DefaultCacheTTL.
+func NewCachingProvider(underlying Provider, ttl time.Duration) *CachingProvider {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &CachingProvider{underlying: underlying, ttl: ttl, entries: make(map[string]cacheEntry)}
+}
+
+// Rate implements Provider.
+func (c *CachingProvider) Rate(ctx context.Context, base, quote string) (float64, time.Time, error) {
+	key := base + "_" + quote
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	if ok && time.Now().Before(entry.cacheExpiresAt) {
+		return entry.rate, entry.asOf, nil
+	}
+
+	result, err, _ := c.group.Do(key, func() (interface{}, error) {
+		// A flight that finished between the check above and this one
+		// starting has already refreshed the entry.
+		c.mu.RLock()
+		entry, ok := c.entries[key]
+		c.mu.RUnlock()
+		if ok && time.Now().Before(entry.cacheExpiresAt) {
+			return entry, nil
+		}
+		rate, asOf, err := c.underlying.Rate(ctx, base, quote)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.entries[key] = cacheEntry{rate: rate, asOf: asOf, cacheExpiresAt: time.Now().Add(c.ttl)}
+		c.mu.Unlock()
+		return cacheEntry{rate: rate, asOf: asOf}, nil
+	})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	e := result.(cacheEntry)
+	return e.rate, e.asOf, nil
+}
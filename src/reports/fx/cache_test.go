@@ -0,0 +1,58 @@
+package fx
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingProvider struct {
+	calls int32
+	rate  float64
+}
+
+func (c *countingProvider) Rate(ctx context.Context, base, quote string) (float64, time.Time, error) {
+	atomic.AddInt32(&c.calls, 1)
+	return c.rate, time.Now(), nil
+}
+
+func TestCachingProviderServesFromCacheWithinTTL(t *testing.T) {
+	underlying := &countingProvider{rate: 1800}
+	cached := NewCachingProvider(underlying, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		rate, _, err := cached.Rate(context.Background(), "ETH", "USD")
+		if err != nil {
+			t.Fatalf("Rate returned error: %v", err)
+		}
+		if rate != 1800 {
+			t.Fatalf("expected rate 1800, got %v", rate)
+		}
+	}
+	if calls := atomic.LoadInt32(&underlying.calls); calls != 1 {
+		t.Fatalf("expected 1 upstream call, got %v", calls)
+	}
+}
+
+func TestCachingProviderCoalescesConcurrentLookups(t *testing.T) {
+	underlying := &countingProvider{rate: 42}
+	cached := NewCachingProvider(underlying, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := cached.Rate(context.Background(), "BTC", "USD"); err != nil {
+				t.Errorf("Rate returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls := atomic.LoadInt32(&underlying.calls); calls != 1 {
+		t.Fatalf("expected concurrent lookups to coalesce into 1 upstream call, got %v", calls)
+	}
+}
@@ -0,0 +1,79 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// coinGeckoSimplePriceURL is CoinGecko's simple price endpoint.
+const coinGeckoSimplePriceURL = "https://api.coingecko.com/api/v3/simple/price"
+
+// defaultCoinGeckoIDs maps the coin symbols this module cares about to
+// their CoinGecko asset ids.
+var defaultCoinGeckoIDs = map[string]string{
+	"BTC": "bitcoin",
+	"ETH": "ethereum",
+	"ETC": "ethereum-classic",
+	"RVN": "ravencoin",
+}
+
+// CoinGeckoProvider prices crypto-to-fiat pairs using CoinGecko's
+// public API. It looks up the CoinGecko asset id for base in IDs,
+// falling back to defaultCoinGeckoIDs.
+type CoinGeckoProvider struct {
+	IDs    map[string]string
+	client *http.Client
+}
+
+// NewCoinGeckoProvider constructs a CoinGeckoProvider. ids overrides or
+// extends defaultCoinGeckoIDs; pass nil to use the defaults as-is.
+func NewCoinGeckoProvider(ids map[string]string) *CoinGeckoProvider {
+	merged := make(map[string]string, len(defaultCoinGeckoIDs)+len(ids))
+	for k, v := range defaultCoinGeckoIDs {
+		merged[k] = v
+	}
+	for k, v := range ids {
+		merged[k] = v
+	}
+	return &CoinGeckoProvider{IDs: merged, client: http.DefaultClient}
+}
+
+// Rate implements Provider.
+func (c *CoinGeckoProvider) Rate(ctx context.Context, base, quote string) (float64, time.Time, error) {
+	id, ok := c.IDs[strings.ToUpper(base)]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("fx: coingecko: no asset id configured for %s", base)
+	}
+	vsCurrency := strings.ToLower(quote)
+	url := fmt.Sprintf("%s?ids=%s&vs_currencies=%s", coinGeckoSimplePriceURL, id, vsCurrency)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	var parsed map[string]map[string]float64
+	if err := json.Unmarshal(bodyBytes, &parsed); err != nil {
+		return 0, time.Time{}, err
+	}
+	rate, ok := parsed[id][vsCurrency]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("fx: coingecko: no rate returned for %s/%s", id, vsCurrency)
+	}
+	return rate, time.Now(), nil
+}
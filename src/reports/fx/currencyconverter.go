@@ -0,0 +1,59 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// currencyConverterBaseURL is the free.currencyconverterapi.com compact
+// conversion endpoint.
+const currencyConverterBaseURL = "https://free.currencyconverterapi.com/api/v5/convert"
+
+// CurrencyConverterProvider prices fiat-to-fiat pairs (eg USD->CAD)
+// using free.currencyconverterapi.com. It does not support crypto
+// bases; use CoinGeckoProvider for those.
+type CurrencyConverterProvider struct {
+	client *http.Client
+}
+
+// NewCurrencyConverterProvider constructs a CurrencyConverterProvider.
+func NewCurrencyConverterProvider() *CurrencyConverterProvider {
+	return &CurrencyConverterProvider{client: http.DefaultClient}
+}
+
+// Rate implements Provider.
+func (c *CurrencyConverterProvider) Rate(ctx context.Context, base, quote string) (float64, time.Time, error) {
+	pair := base + "_" + quote
+	url := fmt.Sprintf("%s?q=%s&compact=y", currencyConverterBaseURL, pair)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	var parsed map[string]struct {
+		Val float64 `json:"val"`
+	}
+	if err := json.Unmarshal(bodyBytes, &parsed); err != nil {
+		return 0, time.Time{}, err
+	}
+	entry, ok := parsed[pair]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("fx: currencyconverter: no rate returned for %s", pair)
+	}
+	return entry.Val, time.Now(), nil
+}
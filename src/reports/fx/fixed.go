@@ -0,0 +1,29 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// FixedProvider returns a canned rate for each "BASE_QUOTE" pair. It's
+// used in tests that need deterministic fiat values without making
+// network calls.
+type FixedProvider struct {
+	Rates map[string]float64
+}
+
+// NewFixedProvider constructs a FixedProvider from the given rates,
+// keyed by "BASE_QUOTE", eg map[string]float64{"ETH_USD": 1800}.
+func NewFixedProvider(rates map[string]float64) *FixedProvider {
+	return &FixedProvider{Rates: rates}
+}
+
+// Rate implements Provider.
+func (f *FixedProvider) Rate(ctx context.Context, base, quote string) (float64, time.Time, error) {
+	rate, ok := f.Rates[base+"_"+quote]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("fx: no fixed rate configured for %s_%s", base, quote)
+	}
+	return rate, time.Unix(0, 0), nil
+}
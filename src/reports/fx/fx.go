@@ -0,0 +1,16 @@
+// Package fx abstracts over the source used to price a coin in one or
+// more fiat currencies, so a long-running report process can refresh
+// rates on every send instead of freezing them at startup.
+package fx
+
+import (
+	"context"
+	"time"
+)
+
+// Provider looks up the exchange rate between base and quote, eg
+// base="ETH" quote="USD", returning the rate and the time it was
+// observed as of.
+type Provider interface {
+	Rate(ctx context.Context, base, quote string) (rate float64, asOf time.Time, err error)
+}
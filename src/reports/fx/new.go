@@ -0,0 +1,35 @@
+package fx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+)
+
+// Supported values for config.Config.FXProvider.
+const (
+	ProviderCurrencyConverter = "currencyconverter"
+	ProviderCoinGecko         = "coingecko"
+	ProviderFixed             = "fixed"
+)
+
+// New constructs the Provider configured by cfg.FXProvider, wrapped in
+// a TTL cache, defaulting to CoinGecko since it's the only provider
+// here that can price a coin directly against fiat.
+func New(cfg *config.Config) (Provider, error) {
+	var underlying Provider
+	switch cfg.FXProvider {
+	case "", ProviderCoinGecko:
+		underlying = NewCoinGeckoProvider(cfg.CoinGeckoIDs)
+	case ProviderCurrencyConverter:
+		underlying = NewCurrencyConverterProvider()
+	case ProviderFixed:
+		underlying = NewFixedProvider(cfg.FXFixedRates)
+	default:
+		return nil, fmt.Errorf("unsupported fx provider %q", cfg.FXProvider)
+	}
+
+	ttl := time.Duration(cfg.FXCacheTTLSeconds) * time.Second
+	return NewCachingProvider(underlying, ttl), nil
+}
@@ -0,0 +1,70 @@
+// Package mailer abstracts over the transport used to deliver report
+// emails, so report generation can be tested without talking to a
+// provider and farm operators can pick whatever transport they already
+// use.
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+)
+
+// Message is a provider-agnostic representation of an email to send.
+type Message struct {
+	FromName    string
+	FromEmail   string
+	ToName      string
+	ToEmail     string
+	Subject     string
+	ContentType string
+	Content     string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a Message, either inline (shown in
+// the body via "cid:ContentID") or as a regular attachment.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+	// ContentID, when set, makes this an inline attachment referenced
+	// from the HTML body as <img src="cid:ContentID">.
+	ContentID string
+}
+
+// Mailer is implemented by every mail transport this module supports.
+type Mailer interface {
+	// Send delivers msg and returns the provider's status code.
+	Send(ctx context.Context, msg Message) (statusCode int, err error)
+}
+
+// New constructs the Mailer configured by cfg.MailProvider. It defaults
+// to the Sendgrid provider to preserve existing behavior when the field
+// is left unset.
+func New(cfg *config.Config) (Mailer, error) {
+	switch cfg.MailProvider {
+	case "", ProviderSendgrid:
+		return NewSendGridMailer(cfg.SendgridAPIKey), nil
+	case ProviderSMTP:
+		return NewSMTPMailer(cfg.SMTP), nil
+	case ProviderMailgun:
+		return NewMailgunMailer(cfg.Mailgun), nil
+	case ProviderMandrill:
+		return NewMandrillMailer(cfg.Mandrill), nil
+	case ProviderNull:
+		return NewNullMailer(), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail provider %q", cfg.MailProvider)
+	}
+}
+
+// Supported values for config.Config.MailProvider.
+const (
+	ProviderSendgrid = "sendgrid"
+	ProviderSMTP     = "smtp"
+	ProviderMailgun  = "mailgun"
+	ProviderMandrill = "mandrill"
+	ProviderNull     = "null"
+)
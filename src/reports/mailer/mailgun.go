@@ -0,0 +1,92 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+)
+
+const defaultMailgunAPIBase = "https://api.mailgun.net/v3"
+
+// MailgunMailer sends mail through Mailgun's HTTP API.
+type MailgunMailer struct {
+	cfg    config.MailgunConfig
+	client *http.Client
+}
+
+// NewMailgunMailer constructs a MailgunMailer from cfg.
+func NewMailgunMailer(cfg config.MailgunConfig) *MailgunMailer {
+	if cfg.APIBase == "" {
+		cfg.APIBase = defaultMailgunAPIBase
+	}
+	return &MailgunMailer{cfg: cfg, client: http.DefaultClient}
+}
+
+// Send implements Mailer.
+func (m *MailgunMailer) Send(ctx context.Context, msg Message) (int, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	if err := w.WriteField("from", addressHeader(msg.FromName, msg.FromEmail)); err != nil {
+		return 0, err
+	}
+	if err := w.WriteField("to", addressHeader(msg.ToName, msg.ToEmail)); err != nil {
+		return 0, err
+	}
+	if err := w.WriteField("subject", msg.Subject); err != nil {
+		return 0, err
+	}
+	field := "text"
+	if strings.Contains(msg.ContentType, "html") {
+		field = "html"
+	}
+	if err := w.WriteField(field, msg.Content); err != nil {
+		return 0, err
+	}
+
+	for _, a := range msg.Attachments {
+		formField := "attachment"
+		if a.ContentID != "" {
+			formField = "inline"
+		}
+		part, err := w.CreateFormFile(formField, a.Filename)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := part.Write(a.Content); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return 0, err
+	}
+
+	endpoint := m.cfg.APIBase + "/" + m.cfg.Domain + "/messages"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return 0, err
+	}
+	req.SetBasicAuth("api", m.cfg.APIKey)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// addressHeader formats a name/email pair the way RFC 5322 "From"/"To"
+// headers expect, falling back to a bare address when name is empty.
+func addressHeader(name, email string) string {
+	if name == "" {
+		return email
+	}
+	return name + " <" + email + ">"
+}
@@ -0,0 +1,101 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+)
+
+const mandrillSendURL = "https://mandrillapp.com/api/1.0/messages/send.json"
+
+// MandrillMailer sends mail through Mandrill's HTTP API.
+type MandrillMailer struct {
+	cfg    config.MandrillConfig
+	client *http.Client
+}
+
+// NewMandrillMailer constructs a MandrillMailer from cfg.
+func NewMandrillMailer(cfg config.MandrillConfig) *MandrillMailer {
+	return &MandrillMailer{cfg: cfg, client: http.DefaultClient}
+}
+
+type mandrillMessage struct {
+	HTML        string               `json:"html,omitempty"`
+	Text        string               `json:"text,omitempty"`
+	Subject     string               `json:"subject"`
+	FromEmail   string               `json:"from_email"`
+	FromName    string               `json:"from_name"`
+	To          []mandrillRecipient  `json:"to"`
+	Images      []mandrillAttachment `json:"images,omitempty"`
+	Attachments []mandrillAttachment `json:"attachments,omitempty"`
+}
+
+type mandrillRecipient struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type"`
+}
+
+// mandrillAttachment is shared by the "images" (inline, referenced via
+// cid:Name) and "attachments" fields of a send request.
+type mandrillAttachment struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+type mandrillSendRequest struct {
+	Key     string          `json:"key"`
+	Message mandrillMessage `json:"message"`
+}
+
+// Send implements Mailer.
+func (m *MandrillMailer) Send(ctx context.Context, msg Message) (int, error) {
+	mMsg := mandrillMessage{
+		Subject:   msg.Subject,
+		FromEmail: msg.FromEmail,
+		FromName:  msg.FromName,
+		To:        []mandrillRecipient{{Email: msg.ToEmail, Name: msg.ToName, Type: "to"}},
+	}
+	if msg.ContentType == "text/plain" {
+		mMsg.Text = msg.Content
+	} else {
+		mMsg.HTML = msg.Content
+	}
+
+	for _, a := range msg.Attachments {
+		attachment := mandrillAttachment{
+			Type:    a.ContentType,
+			Name:    a.Filename,
+			Content: base64.StdEncoding.EncodeToString(a.Content),
+		}
+		if a.ContentID != "" {
+			attachment.Name = a.ContentID
+			mMsg.Images = append(mMsg.Images, attachment)
+		} else {
+			mMsg.Attachments = append(mMsg.Attachments, attachment)
+		}
+	}
+
+	body, err := json.Marshal(mandrillSendRequest{Key: m.cfg.APIKey, Message: mMsg})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, mandrillSendURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,27 @@
+package mailer
+
+import "context"
+
+// MockMailer records every message it's asked to send so tests can
+// assert on report bodies without hitting the network. StatusCode is
+// returned from every call to Send unless Err is set.
+type MockMailer struct {
+	StatusCode int
+	Err        error
+	Sent       []Message
+}
+
+// NewMockMailer constructs a MockMailer that reports a 202 status code
+// by default, matching the Sendgrid success response.
+func NewMockMailer() *MockMailer {
+	return &MockMailer{StatusCode: 202}
+}
+
+// Send implements Mailer.
+func (m *MockMailer) Send(ctx context.Context, msg Message) (int, error) {
+	if m.Err != nil {
+		return 0, m.Err
+	}
+	m.Sent = append(m.Sent, msg)
+	return m.StatusCode, nil
+}
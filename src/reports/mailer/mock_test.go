@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var errSendFailed = errors.New("send failed")
+
+func TestMockMailerRecordsSentMessages(t *testing.T) {
+	m := NewMockMailer()
+	msg := Message{
+		FromEmail:   "sgapi@rtradetechnologies.com",
+		ToEmail:     "reports@rtradetechnologies.com",
+		Subject:     "Ethereum Mining Report",
+		ContentType: "text/html",
+		Content:     "<br>Eth Mined: 1.5",
+	}
+
+	code, err := m.Send(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if code != 202 {
+		t.Fatalf("expected status code 202, got %v", code)
+	}
+	if len(m.Sent) != 1 {
+		t.Fatalf("expected 1 sent message, got %v", len(m.Sent))
+	}
+	if m.Sent[0].Content != msg.Content {
+		t.Fatalf("expected recorded content %q, got %q", msg.Content, m.Sent[0].Content)
+	}
+}
+
+func TestMockMailerRecordsAttachments(t *testing.T) {
+	m := NewMockMailer()
+	msg := Message{
+		ToEmail: "reports@rtradetechnologies.com",
+		Subject: "Ethereum Mining Report",
+		Attachments: []Attachment{
+			{Filename: "chart.png", ContentType: "image/png", Content: []byte{0x89, 'P', 'N', 'G'}, ContentID: "chart.png"},
+		},
+	}
+
+	if _, err := m.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if len(m.Sent) != 1 || len(m.Sent[0].Attachments) != 1 {
+		t.Fatalf("expected 1 sent message with 1 attachment, got %+v", m.Sent)
+	}
+	if m.Sent[0].Attachments[0].ContentID != "chart.png" {
+		t.Fatalf("expected attachment ContentID %q, got %q", "chart.png", m.Sent[0].Attachments[0].ContentID)
+	}
+}
+
+func TestMockMailerReturnsConfiguredError(t *testing.T) {
+	m := NewMockMailer()
+	m.Err = errSendFailed
+
+	if _, err := m.Send(context.Background(), Message{}); err != errSendFailed {
+		t.Fatalf("expected errSendFailed, got %v", err)
+	}
+	if len(m.Sent) != 0 {
+		t.Fatalf("expected no sent messages after a failed send, got %v", len(m.Sent))
+	}
+}
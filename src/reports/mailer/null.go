@@ -0,0 +1,22 @@
+package mailer
+
+import (
+	"context"
+	"log"
+)
+
+// NullMailer logs messages instead of sending them. It's used for
+// dry-run operators and in tests that exercise report generation
+// without talking to the network.
+type NullMailer struct{}
+
+// NewNullMailer constructs a NullMailer.
+func NewNullMailer() *NullMailer {
+	return &NullMailer{}
+}
+
+// Send implements Mailer, logging msg and reporting http.StatusOK (200).
+func (n *NullMailer) Send(ctx context.Context, msg Message) (int, error) {
+	log.Printf("mailer: dry-run send to=%s subject=%q", msg.ToEmail, msg.Subject)
+	return 200, nil
+}
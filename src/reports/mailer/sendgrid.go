@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+
+	sendgrid "github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+// SendGridMailer sends mail through the Sendgrid v3 API.
+type SendGridMailer struct {
+	client *sendgrid.Client
+}
+
+// NewSendGridMailer constructs a SendGridMailer authenticated with apiKey.
+func NewSendGridMailer(apiKey string) *SendGridMailer {
+	return &SendGridMailer{client: sendgrid.NewSendClient(apiKey)}
+}
+
+// Send implements Mailer.
+func (s *SendGridMailer) Send(ctx context.Context, msg Message) (int, error) {
+	from := mail.NewEmail(msg.FromName, msg.FromEmail)
+	to := mail.NewEmail(msg.ToName, msg.ToEmail)
+	content := mail.NewContent(msg.ContentType, msg.Content)
+	email := mail.NewV3MailInit(from, msg.Subject, to, content)
+
+	for _, a := range msg.Attachments {
+		attachment := mail.NewAttachment()
+		attachment.SetContent(base64.StdEncoding.EncodeToString(a.Content))
+		attachment.SetType(a.ContentType)
+		attachment.SetFilename(a.Filename)
+		if a.ContentID != "" {
+			attachment.SetContentID(a.ContentID)
+			attachment.SetDisposition("inline")
+		} else {
+			attachment.SetDisposition("attachment")
+		}
+		email.AddAttachment(attachment)
+	}
+
+	response, err := s.client.Send(email)
+	if err != nil {
+		return 0, err
+	}
+	return response.StatusCode, nil
+}
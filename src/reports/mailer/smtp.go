@@ -0,0 +1,92 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+)
+
+// SMTPMailer sends mail through a raw SMTP relay using STARTTLS and
+// plain auth.
+type SMTPMailer struct {
+	cfg config.SMTPConfig
+}
+
+// NewSMTPMailer constructs a SMTPMailer from cfg.
+func NewSMTPMailer(cfg config.SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send implements Mailer. SMTP has no notion of a provider status code,
+// so a successful send reports http.StatusOK (200).
+func (s *SMTPMailer) Send(ctx context.Context, msg Message) (int, error) {
+	addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
+	auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+
+	body, contentType, err := buildMIMEBody(msg)
+	if err != nil {
+		return 0, err
+	}
+	header := fmt.Sprintf(
+		"From: %s <%s>\r\nTo: %s <%s>\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: %s\r\n\r\n",
+		msg.FromName, msg.FromEmail, msg.ToName, msg.ToEmail, msg.Subject, contentType,
+	)
+
+	if err := smtp.SendMail(addr, auth, msg.FromEmail, []string{msg.ToEmail}, append([]byte(header), body...)); err != nil {
+		return 0, err
+	}
+	return 200, nil
+}
+
+// buildMIMEBody renders msg as a multipart/related body when it has
+// attachments, or a plain body otherwise, returning the body and the
+// Content-Type header value to use for it.
+func buildMIMEBody(msg Message) ([]byte, string, error) {
+	if len(msg.Attachments) == 0 {
+		return []byte(msg.Content), msg.ContentType + `; charset="UTF-8"`, nil
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	partHeader := textproto.MIMEHeader{}
+	partHeader.Set("Content-Type", msg.ContentType+`; charset="UTF-8"`)
+	part, err := w.CreatePart(partHeader)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write([]byte(msg.Content)); err != nil {
+		return nil, "", err
+	}
+
+	for _, a := range msg.Attachments {
+		attachmentHeader := textproto.MIMEHeader{}
+		attachmentHeader.Set("Content-Type", a.ContentType)
+		attachmentHeader.Set("Content-Transfer-Encoding", "base64")
+		if a.ContentID != "" {
+			attachmentHeader.Set("Content-ID", "<"+a.ContentID+">")
+			attachmentHeader.Set("Content-Disposition", `inline; filename="`+a.Filename+`"`)
+		} else {
+			attachmentHeader.Set("Content-Disposition", `attachment; filename="`+a.Filename+`"`)
+		}
+		attachmentPart, err := w.CreatePart(attachmentHeader)
+		if err != nil {
+			return nil, "", err
+		}
+		encoded := base64.StdEncoding.EncodeToString(a.Content)
+		if _, err := attachmentPart.Write([]byte(encoded)); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), `multipart/related; boundary="` + w.Boundary() + `"`, nil
+}
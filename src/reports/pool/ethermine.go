@@ -0,0 +1,90 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// EthermineClient talks to Ethermine's public miner API
+// (https://ethermine.org/api). coin is accepted on every Client method
+// for interface symmetry but is ignored, since an Ethermine account is
+// scoped to a single address/coin pair.
+type EthermineClient struct {
+	Address string
+	client  *http.Client
+}
+
+// NewEthermineClient constructs an EthermineClient for the given miner
+// address.
+func NewEthermineClient(address string) *EthermineClient {
+	return &EthermineClient{Address: address, client: http.DefaultClient}
+}
+
+type etherminePayoutsResponse struct {
+	Data []struct {
+		Amount float64 `json:"amount"`
+		PaidOn int64   `json:"paidOn"`
+	} `json:"data"`
+}
+
+// RecentCredits24h implements Client, summing payouts recorded by
+// Ethermine in the last 24 hours.
+func (c *EthermineClient) RecentCredits24h(ctx context.Context, coin string) (Credits, error) {
+	history, err := c.RecentCreditsHistory(ctx, coin)
+	if err != nil {
+		return Credits{}, err
+	}
+	return sumRecentPayouts(history, time.Now()), nil
+}
+
+// sumRecentPayouts sums the amount of every payout in history whose
+// Timestamp falls within the 24 hours before now, pairing the sum with
+// the latest timestamp among them.
+func sumRecentPayouts(history []Credits, now time.Time) Credits {
+	cutoff := now.Add(-24 * time.Hour).Unix()
+	var sum Credits
+	for _, payout := range history {
+		if payout.Timestamp < cutoff {
+			continue
+		}
+		sum.Amount += payout.Amount
+		if payout.Timestamp > sum.Timestamp {
+			sum.Timestamp = payout.Timestamp
+		}
+	}
+	return sum
+}
+
+// RecentCreditsHistory implements Client, returning one Credits entry
+// per payout Ethermine has on record.
+func (c *EthermineClient) RecentCreditsHistory(ctx context.Context, coin string) ([]Credits, error) {
+	url := fmt.Sprintf("https://api.ethermine.org/miner/%v/payouts", c.Address)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var parsed etherminePayoutsResponse
+	if err := json.Unmarshal(bodyBytes, &parsed); err != nil {
+		return nil, err
+	}
+
+	credits := make([]Credits, len(parsed.Data))
+	for i, p := range parsed.Data {
+		credits[i] = Credits{Amount: p.Amount, Timestamp: p.PaidOn}
+	}
+	return credits, nil
+}
@@ -0,0 +1,30 @@
+package pool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSumRecentPayoutsFiltersToLast24Hours(t *testing.T) {
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	history := []Credits{
+		{Amount: 1, Timestamp: now.Add(-48 * time.Hour).Unix()}, // too old, excluded
+		{Amount: 2, Timestamp: now.Add(-10 * time.Hour).Unix()},
+		{Amount: 3, Timestamp: now.Add(-1 * time.Hour).Unix()},
+	}
+
+	got := sumRecentPayouts(history, now)
+	if got.Amount != 5 {
+		t.Fatalf("expected amount 5, got %v", got.Amount)
+	}
+	if got.Timestamp != now.Add(-1*time.Hour).Unix() {
+		t.Fatalf("expected timestamp of the latest payout in window, got %v", got.Timestamp)
+	}
+}
+
+func TestSumRecentPayoutsEmptyHistory(t *testing.T) {
+	got := sumRecentPayouts(nil, time.Now())
+	if got.Amount != 0 || got.Timestamp != 0 {
+		t.Fatalf("expected zero-value Credits for empty history, got %+v", got)
+	}
+}
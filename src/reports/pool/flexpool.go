@@ -0,0 +1,71 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// FlexpoolClient talks to Flexpool's public API
+// (https://flexpool.io/docs/api).
+type FlexpoolClient struct {
+	Address string
+	Coin    string
+	client  *http.Client
+}
+
+// NewFlexpoolClient constructs a FlexpoolClient for the given miner
+// address and coin, eg "eth" or "etc".
+func NewFlexpoolClient(address, coin string) *FlexpoolClient {
+	return &FlexpoolClient{Address: address, Coin: coin, client: http.DefaultClient}
+}
+
+type flexpoolRoundsResponse struct {
+	Result []struct {
+		Reward    float64 `json:"reward"`
+		Timestamp int64   `json:"timestamp"`
+	} `json:"result"`
+}
+
+// RecentCredits24h implements Client, summing confirmed round rewards
+// Flexpool recorded in the last 24 hours.
+func (c *FlexpoolClient) RecentCredits24h(ctx context.Context, coin string) (Credits, error) {
+	history, err := c.RecentCreditsHistory(ctx, coin)
+	if err != nil {
+		return Credits{}, err
+	}
+	return sumRecentPayouts(history, time.Now()), nil
+}
+
+// RecentCreditsHistory implements Client, returning one Credits entry
+// per confirmed round Flexpool has on record for the account.
+func (c *FlexpoolClient) RecentCreditsHistory(ctx context.Context, coin string) ([]Credits, error) {
+	url := fmt.Sprintf("https://api.flexpool.io/v2/miner/rounds?coin=%v&address=%v", c.Coin, c.Address)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var parsed flexpoolRoundsResponse
+	if err := json.Unmarshal(bodyBytes, &parsed); err != nil {
+		return nil, err
+	}
+
+	credits := make([]Credits, len(parsed.Result))
+	for i, r := range parsed.Result {
+		credits[i] = Credits{Amount: r.Reward, Timestamp: r.Timestamp}
+	}
+	return credits, nil
+}
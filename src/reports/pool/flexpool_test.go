@@ -0,0 +1,52 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rewriteHostTransport sends every request to target, so a client with
+// a hard-coded API host can be pointed at an httptest server.
+type rewriteHostTransport struct {
+	target *url.URL
+}
+
+func (t rewriteHostTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestFlexpoolRecentCredits24hSumsLast24Hours(t *testing.T) {
+	now := time.Now()
+	tooOld := now.Add(-48 * time.Hour).Unix() // too old, excluded
+	older := now.Add(-10 * time.Hour).Unix()
+	latest := now.Add(-1 * time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"result":[{"reward":1,"timestamp":%d},{"reward":2,"timestamp":%d},{"reward":3,"timestamp":%d}]}`, tooOld, older, latest)
+	}))
+	defer server.Close()
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("url.Parse returned error: %v", err)
+	}
+
+	c := NewFlexpoolClient("0xabc", "eth")
+	c.client = &http.Client{Transport: rewriteHostTransport{target: target}}
+	got, err := c.RecentCredits24h(context.Background(), "eth")
+	if err != nil {
+		t.Fatalf("RecentCredits24h returned error: %v", err)
+	}
+	if got.Amount != 5 {
+		t.Fatalf("expected amount 5, got %v", got.Amount)
+	}
+	if got.Timestamp != latest {
+		t.Fatalf("expected timestamp of the latest round in window, got %v", got.Timestamp)
+	}
+}
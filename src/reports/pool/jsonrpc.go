@@ -0,0 +1,103 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// JSONRPCClient talks to any mining pool exposing a JSON-RPC endpoint
+// that returns credits in the shape {"result": [{"amount":..,
+// "timestamp":..}, ...]}, eg many stratum-proxy style dashboards. It's
+// the fallback used for pools this module doesn't have a dedicated
+// client for.
+type JSONRPCClient struct {
+	Endpoint string
+	// Method is the JSON-RPC method invoked to fetch credit history,
+	// eg "miner.getCredits".
+	Method string
+	client *http.Client
+	nextID int
+}
+
+// NewJSONRPCClient constructs a JSONRPCClient against the given
+// endpoint and method.
+func NewJSONRPCClient(endpoint, method string) *JSONRPCClient {
+	return &JSONRPCClient{Endpoint: endpoint, Method: method, client: http.DefaultClient}
+}
+
+type jsonrpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type jsonrpcResponse struct {
+	Result []struct {
+		Amount    float64 `json:"amount"`
+		Timestamp int64   `json:"timestamp"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// RecentCredits24h implements Client, summing entries in the pool's
+// credit history recorded in the last 24 hours.
+func (c *JSONRPCClient) RecentCredits24h(ctx context.Context, coin string) (Credits, error) {
+	history, err := c.RecentCreditsHistory(ctx, coin)
+	if err != nil {
+		return Credits{}, err
+	}
+	return sumRecentPayouts(history, time.Now()), nil
+}
+
+// RecentCreditsHistory implements Client, calling Method with coin as
+// its sole parameter.
+func (c *JSONRPCClient) RecentCreditsHistory(ctx context.Context, coin string) ([]Credits, error) {
+	c.nextID++
+	reqBody, err := json.Marshal(jsonrpcRequest{
+		JSONRPC: "2.0",
+		ID:      c.nextID,
+		Method:  c.Method,
+		Params:  []interface{}{coin},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var parsed jsonrpcResponse
+	if err := json.Unmarshal(bodyBytes, &parsed); err != nil {
+		return nil, err
+	}
+	if parsed.Error != nil {
+		return nil, fmt.Errorf("pool: jsonrpc error: %s", parsed.Error.Message)
+	}
+
+	credits := make([]Credits, len(parsed.Result))
+	for i, r := range parsed.Result {
+		credits[i] = Credits{Amount: r.Amount, Timestamp: r.Timestamp}
+	}
+	return credits, nil
+}
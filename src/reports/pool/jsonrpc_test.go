@@ -0,0 +1,33 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestJSONRPCRecentCredits24hSumsLast24Hours(t *testing.T) {
+	now := time.Now()
+	tooOld := now.Add(-48 * time.Hour).Unix() // too old, excluded
+	older := now.Add(-10 * time.Hour).Unix()
+	latest := now.Add(-1 * time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":[{"amount":1,"timestamp":%d},{"amount":2,"timestamp":%d},{"amount":3,"timestamp":%d}]}`, tooOld, older, latest)
+	}))
+	defer server.Close()
+
+	c := NewJSONRPCClient(server.URL, "miner.getCredits")
+	got, err := c.RecentCredits24h(context.Background(), "eth")
+	if err != nil {
+		t.Fatalf("RecentCredits24h returned error: %v", err)
+	}
+	if got.Amount != 5 {
+		t.Fatalf("expected amount 5, got %v", got.Amount)
+	}
+	if got.Timestamp != latest {
+		t.Fatalf("expected timestamp of the latest entry in window, got %v", got.Timestamp)
+	}
+}
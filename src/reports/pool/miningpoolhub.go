@@ -0,0 +1,108 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// miningPoolHubAPIResponse mirrors the envelope MiningPoolHub wraps its
+// dashboard data in, keyed by the action name that was requested.
+type miningPoolHubAPIResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// MiningPoolHubClient talks to MiningPoolHub's getdashboarddata
+// endpoint. Unlike the original implementation this keeps its URL
+// template as instance state rather than mutating shared config, so
+// concurrent calls and multi-coin farms don't corrupt each other.
+type MiningPoolHubClient struct {
+	// URLTemplate is formatted with a coin, an action name, and an API
+	// key, in that order, eg
+	// "https://%v.miningpoolhub.com/index.php?page=api&action=%v&api_key=%v".
+	URLTemplate string
+	APIKey      string
+	client      *http.Client
+}
+
+// NewMiningPoolHubClient constructs a MiningPoolHubClient.
+func NewMiningPoolHubClient(urlTemplate, apiKey string) *MiningPoolHubClient {
+	return &MiningPoolHubClient{URLTemplate: urlTemplate, APIKey: apiKey, client: http.DefaultClient}
+}
+
+// RecentCredits24h implements Client.
+func (c *MiningPoolHubClient) RecentCredits24h(ctx context.Context, coin string) (Credits, error) {
+	data, err := c.fetchDashboardData(ctx, coin)
+	if err != nil {
+		return Credits{}, err
+	}
+	return decodeCredits(data.Data["recent_credits_24hours"])
+}
+
+// RecentCreditsHistory implements Client.
+func (c *MiningPoolHubClient) RecentCreditsHistory(ctx context.Context, coin string) ([]Credits, error) {
+	data, err := c.fetchDashboardData(ctx, coin)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCreditsList(data.Data["recent_credits"])
+}
+
+func (c *MiningPoolHubClient) fetchDashboardData(ctx context.Context, coin string) (*miningPoolHubAPIResponse, error) {
+	const action = "getdashboarddata"
+	url := fmt.Sprintf(c.URLTemplate, coin, action, c.APIKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var intf map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &intf); err != nil {
+		return nil, err
+	}
+	marshaled, err := json.Marshal(intf[action])
+	if err != nil {
+		return nil, err
+	}
+	var data miningPoolHubAPIResponse
+	if err := json.Unmarshal(marshaled, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func decodeCredits(v interface{}) (Credits, error) {
+	marshaled, err := json.Marshal(v)
+	if err != nil {
+		return Credits{}, err
+	}
+	var credits Credits
+	if err := json.Unmarshal(marshaled, &credits); err != nil {
+		return Credits{}, err
+	}
+	return credits, nil
+}
+
+func decodeCreditsList(v interface{}) ([]Credits, error) {
+	marshaled, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var credits []Credits
+	if err := json.Unmarshal(marshaled, &credits); err != nil {
+		return nil, err
+	}
+	return credits, nil
+}
@@ -0,0 +1,45 @@
+package pool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiningPoolHubClientRecentCredits24h(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"getdashboarddata":{"data":{"recent_credits_24hours":{"amount":1.5,"timestamp":1000}}}}`))
+	}))
+	defer server.Close()
+
+	c := NewMiningPoolHubClient(server.URL+"?coin=%v&action=%v&key=%v", "apikey")
+	credits, err := c.RecentCredits24h(context.Background(), "eth")
+	if err != nil {
+		t.Fatalf("RecentCredits24h returned error: %v", err)
+	}
+	if credits.Amount != 1.5 {
+		t.Fatalf("expected amount 1.5, got %v", credits.Amount)
+	}
+	if credits.Timestamp != 1000 {
+		t.Fatalf("expected timestamp 1000, got %v", credits.Timestamp)
+	}
+}
+
+func TestMiningPoolHubClientDoesNotMutateURLTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"getdashboarddata":{"data":{"recent_credits_24hours":{"amount":1,"timestamp":1}}}}`))
+	}))
+	defer server.Close()
+
+	template := server.URL + "?coin=%v&action=%v&key=%v"
+	c := NewMiningPoolHubClient(template, "apikey")
+	for i := 0; i < 3; i++ {
+		if _, err := c.RecentCredits24h(context.Background(), "eth"); err != nil {
+			t.Fatalf("call %d returned error: %v", i, err)
+		}
+		if c.URLTemplate != template {
+			t.Fatalf("URLTemplate was mutated: %v", c.URLTemplate)
+		}
+	}
+}
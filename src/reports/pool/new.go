@@ -0,0 +1,41 @@
+package pool
+
+import (
+	"fmt"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+)
+
+// Supported values for config.Config.PoolProvider.
+const (
+	ProviderMiningPoolHub = "miningpoolhub"
+	ProviderEthermine     = "ethermine"
+	ProviderFlexpool      = "flexpool"
+	ProviderJSONRPC       = "jsonrpc"
+)
+
+// defaultMiningPoolHubURLTemplate matches the template the original
+// MiningPoolHub integration formatted m.Config.URL with.
+const defaultMiningPoolHubURLTemplate = "https://%v.miningpoolhub.com/index.php?page=api&action=%v&api_key=%v"
+
+// New constructs the Client configured by cfg.PoolProvider. It defaults
+// to MiningPoolHub to preserve existing behavior when the field is left
+// unset.
+func New(cfg *config.Config) (Client, error) {
+	switch cfg.PoolProvider {
+	case "", ProviderMiningPoolHub:
+		urlTemplate := cfg.URL
+		if urlTemplate == "" {
+			urlTemplate = defaultMiningPoolHubURLTemplate
+		}
+		return NewMiningPoolHubClient(urlTemplate, cfg.APIKey), nil
+	case ProviderEthermine:
+		return NewEthermineClient(cfg.Ethermine.Address), nil
+	case ProviderFlexpool:
+		return NewFlexpoolClient(cfg.Flexpool.Address, cfg.Flexpool.Coin), nil
+	case ProviderJSONRPC:
+		return NewJSONRPCClient(cfg.JSONRPC.Endpoint, cfg.JSONRPC.Method), nil
+	default:
+		return nil, fmt.Errorf("unsupported pool provider %q", cfg.PoolProvider)
+	}
+}
@@ -0,0 +1,23 @@
+// Package pool abstracts over the mining pool a farm reports credits
+// from, so Manager isn't wedded to MiningPoolHub's endpoint shape.
+package pool
+
+import (
+	"context"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/types"
+)
+
+// Credits is the amount of a coin credited to a pool account over some
+// reporting interval.
+type Credits = types.RecentCredits
+
+// Client is implemented by every pool backend this module supports.
+type Client interface {
+	// RecentCredits24h returns the credits accrued for coin over the
+	// last 24 hour period.
+	RecentCredits24h(ctx context.Context, coin string) (Credits, error)
+	// RecentCreditsHistory returns credits accrued for coin over the
+	// pool's default historical window, broken down per interval.
+	RecentCreditsHistory(ctx context.Context, coin string) ([]Credits, error)
+}
@@ -1,15 +1,19 @@
 package reports
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"sort"
+	"time"
 
 	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/fx"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/mailer"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/pool"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/store"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/templates"
 	"github.com/RTradeLtd/mining-bootstrap/src/reports/types"
-	sendgrid "github.com/sendgrid/sendgrid-go"
-	"github.com/sendgrid/sendgrid-go/helpers/mail"
 )
 
 /*
@@ -17,19 +21,40 @@ This is used to handle automated mining reports for cryptocurrency mining farms
 The idea is to create an easy to use system that can be used by farm operators to create accurate book reports for the tax man
 */
 
-const (
-	// USDAPI is the URL We use to query for USD->CAD conversion
-	USDAPI = "https://free.currencyconverterapi.com/api/v5/convert?q=USD_CAD&compact=y"
-)
+// defaultTargetCurrencies is used when config.Config.TargetCurrencies
+// is left empty, preserving the original USD+CAD report.
+var defaultTargetCurrencies = []string{"USD", "CAD"}
 
 var methodList = []string{"24hour_credit", "credit"}
 
+// DefaultRecipientName and DefaultRecipientEmail are the "to" address
+// a report is sent to when ReportOptions.Recipient is left unset.
+const (
+	DefaultRecipientName  = "Mining Reports"
+	DefaultRecipientEmail = "reports@rtradetechnologies.com"
+)
+
 // Manager is a helper struct used for report generation
 type Manager struct {
-	Config         *config.Config   `json:"config"`
-	EthUSD         float64          `json:"eth_usd"` // keeps track of the ETH->USD conversion ratio
-	UsdCad         float64          `json:"usd_cad"` // keeps track of the USD -> USD conversion ratio
-	SendgridClient *sendgrid.Client `json:"sendgrid_client"`
+	Config *config.Config `json:"config"`
+	Mailer mailer.Mailer  `json:"-"`
+	Pool   pool.Client    `json:"-"`
+	FX     fx.Provider    `json:"-"`
+	// Store persists every fetched credit sample for later tax report
+	// aggregation. It's nil when Config.ReportDBPath is empty.
+	Store *store.Store `json:"-"`
+	// Templates renders report emails from html/template files on
+	// disk. It's nil when Config.TemplateDir is empty.
+	Templates *templates.Renderer `json:"-"`
+}
+
+// templateSubjects maps a report template name to the subject line
+// used when it's sent.
+var templateSubjects = map[string]string{
+	templates.TwentyFourHourCredit: "Ethereum Mining Report",
+	templates.Credit:               "Mining Credit Report",
+	templates.TaxSummary:           "Mining Tax Summary",
+	templates.SilentAlert:          "Mining Farm Silent Alert",
 }
 
 // GenerateReportManagerFromFile is used to generate our helper struct from the config file
@@ -38,34 +63,162 @@ func GenerateReportManagerFromFile(path string) (*Manager, error) {
 	if err != nil {
 		return nil, err
 	}
-	usd, err := ParseUSDCAD()
+	m, err := mailer.New(cfg)
 	if err != nil {
 		return nil, err
 	}
-	eth, err := ParseETHUSD()
+	p, err := pool.New(cfg)
 	if err != nil {
 		return nil, err
 	}
-	return &Manager{Config: cfg, EthUSD: eth, UsdCad: usd, SendgridClient: sendgrid.NewSendClient(cfg.SendgridAPIKey)}, nil
+	rates, err := fx.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var db *store.Store
+	if cfg.ReportDBPath != "" {
+		db, err = store.Open(cfg.ReportDBPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var renderer *templates.Renderer
+	if cfg.TemplateDir != "" {
+		renderer, err = templates.New(cfg.TemplateDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Manager{Config: cfg, Mailer: m, Pool: p, FX: rates, Store: db, Templates: renderer}, nil
+}
 
+// poolName identifies the configured pool backend for storage purposes.
+func (m *Manager) poolName() string {
+	if m.Config.PoolProvider == "" {
+		return pool.ProviderMiningPoolHub
+	}
+	return m.Config.PoolProvider
+}
+
+// PoolName is the exported form of poolName, for callers outside this
+// package (eg reports/scheduler) that need to label output with the
+// configured pool backend.
+func (m *Manager) PoolName() string {
+	return m.poolName()
+}
+
+// targetCurrencies returns the fiat currencies a report should price
+// Coin in, falling back to defaultTargetCurrencies when unconfigured.
+func (m *Manager) targetCurrencies() []string {
+	if len(m.Config.TargetCurrencies) == 0 {
+		return defaultTargetCurrencies
+	}
+	return m.Config.TargetCurrencies
+}
+
+// fiatValues prices amount of Coin in every currency in currencies,
+// returning both the converted values and the rate snapshot used to
+// compute them.
+func (m *Manager) fiatValues(ctx context.Context, amount float64, currencies []string) (values, rates map[string]float64, err error) {
+	values = make(map[string]float64)
+	rates = make(map[string]float64)
+	for _, currency := range currencies {
+		rate, _, err := m.FX.Rate(ctx, m.Config.Coin, currency)
+		if err != nil {
+			return nil, nil, err
+		}
+		rates[currency] = rate
+		values[currency] = amount * rate
+	}
+	return values, rates, nil
+}
+
+// ReportOptions overrides a single report send's recipient, target
+// currencies, and mail transport. The zero value reproduces the
+// defaults CreateReportAndSend has always used. It exists so callers
+// like reports/scheduler can route a job to the recipients and
+// currencies it was configured with, and fail over to a different
+// Mailer after repeated errors, without mutating the shared Manager.
+type ReportOptions struct {
+	// Recipient overrides DefaultRecipientEmail when set.
+	Recipient string
+	// Currencies overrides Manager.targetCurrencies() when non-empty.
+	Currencies []string
+	// Mailer overrides Manager.Mailer for this send when set.
+	Mailer mailer.Mailer
+}
+
+// mailerFor returns the Mailer a send should use: opts.Mailer when
+// set, otherwise m.Mailer.
+func (m *Manager) mailerFor(opts ReportOptions) mailer.Mailer {
+	if opts.Mailer != nil {
+		return opts.Mailer
+	}
+	return m.Mailer
+}
+
+// recipientFor returns the email a send should go to: opts.Recipient
+// when set, otherwise DefaultRecipientEmail.
+func (m *Manager) recipientFor(opts ReportOptions) string {
+	if opts.Recipient != "" {
+		return opts.Recipient
+	}
+	return DefaultRecipientEmail
+}
+
+// currenciesFor returns the currencies a send should be priced in:
+// opts.Currencies when non-empty, otherwise m.targetCurrencies().
+func (m *Manager) currenciesFor(opts ReportOptions) []string {
+	if len(opts.Currencies) > 0 {
+		return opts.Currencies
+	}
+	return m.targetCurrencies()
+}
+
+// recordSample persists a fetched credit sample, a no-op when no Store
+// is configured.
+func (m *Manager) recordSample(ctx context.Context, amount float64, values, rates map[string]float64) error {
+	if m.Store == nil {
+		return nil
+	}
+	return m.Store.RecordSample(ctx, store.Sample{
+		Timestamp:  time.Now(),
+		Coin:       m.Config.Coin,
+		Pool:       m.poolName(),
+		Amount:     amount,
+		FXRates:    rates,
+		FiatValues: values,
+	})
 }
 
 // CreateReportAndSend is used to create and send a mining report
-func (m *Manager) CreateReportAndSend(method string) error {
+func (m *Manager) CreateReportAndSend(ctx context.Context, method string) error {
+	return m.CreateReportAndSendWithOptions(ctx, method, ReportOptions{})
+}
+
+// CreateReportAndSendWithOptions behaves like CreateReportAndSend but
+// applies opts, letting a caller override the recipient, target
+// currencies, and mail transport for this one send. See ReportOptions.
+func (m *Manager) CreateReportAndSendWithOptions(ctx context.Context, method string, opts ReportOptions) error {
 	switch method {
 	case "24hour_credit":
-		credit, err := m.GetRecentCredits24Hours()
+		credit, err := m.GetRecentCredits24Hours(ctx)
 		if err != nil {
 			return err
 		}
-		usdValue := credit.Amount * m.EthUSD
-		cadValue := usdValue * m.UsdCad
-		resp, err := m.Send24HourEmail(credit.Amount, usdValue, cadValue)
+		fiatValues, rates, err := m.fiatValues(ctx, credit.Amount, m.currenciesFor(opts))
 		if err != nil {
 			return err
 		}
-		if resp != 202 {
-			return fmt.Errorf("unacceptable return code, expected 200 got %v", resp)
+		if err := m.recordSample(ctx, credit.Amount, fiatValues, rates); err != nil {
+			return err
+		}
+		resp, err := m.Send24HourEmail(ctx, credit.Amount, fiatValues, rates, opts)
+		if err != nil {
+			return err
+		}
+		if resp < 200 || resp >= 300 {
+			return fmt.Errorf("unacceptable return code, expected 2xx got %v", resp)
 		}
 	case "credit":
 		return fmt.Errorf("not yet supported")
@@ -76,120 +229,215 @@ func (m *Manager) CreateReportAndSend(method string) error {
 }
 
 // GetRecentCredits24Hours is use the get the number of "credits" (credits being number of coins) mined in the last 24 hour period.
-func (m *Manager) GetRecentCredits24Hours() (*types.RecentCredits, error) {
-	s := "getdashboarddata"
-	m.FormatURL(s)
-	resp, err := http.Get(m.Config.URL)
+func (m *Manager) GetRecentCredits24Hours(ctx context.Context) (*types.RecentCredits, error) {
+	credits, err := m.Pool.RecentCredits24h(ctx, m.Config.Coin)
 	if err != nil {
 		return nil, err
 	}
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	return &credits, nil
+}
+
+// GetRecentCredits returns the credit history the configured Pool reports for Coin. The window and the breakdown are the pool backend's own (eg one entry per payout for Ethermine, one per round for Flexpool).
+func (m *Manager) GetRecentCredits(ctx context.Context) (*[]types.RecentCredits, error) {
+	credits, err := m.Pool.RecentCreditsHistory(ctx, m.Config.Coin)
 	if err != nil {
 		return nil, err
 	}
-	var intf map[string]interface{}
-	var data types.MiningPoolHubAPIResponse
-	err = json.Unmarshal(bodyBytes, &intf)
-	if err != nil {
-		return nil, err
+	return &credits, nil
+}
+
+// Send24HourEmail is a function used to send report information for the last 24 hour period
+func (m *Manager) Send24HourEmail(ctx context.Context, amountMined float64, fiatValues, fxSnapshot map[string]float64, opts ...ReportOptions) (int, error) {
+	if m.Templates == nil {
+		return 0, fmt.Errorf("reports: Send24HourEmail requires a configured template directory")
 	}
-	marshaled, err := json.Marshal(intf[s])
-	if err != nil {
-		return nil, err
+	opt := firstReportOptions(opts)
+
+	currencies := sortedCurrencies(fiatValues)
+	chartValues := make([]float64, len(currencies))
+	for i, currency := range currencies {
+		chartValues[i] = fiatValues[currency]
 	}
-	err = json.Unmarshal(marshaled, &data)
+	chartPNG, err := templates.RenderBarChartPNG(fmt.Sprintf("%s 24h Value", m.Config.Coin), currencies, chartValues)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	marshaled, err = json.Marshal(data.Data["recent_credits_24hours"])
+	const chartCID = "chart.png"
+
+	content, err := m.Templates.Render(templates.TwentyFourHourCredit, templates.TwentyFourHourContext{
+		PoolName:    m.poolName(),
+		Coin:        m.Config.Coin,
+		AmountMined: amountMined,
+		FXSnapshot:  fxSnapshot,
+		Conversions: fiatValues,
+		ChartCID:    chartCID,
+		GeneratedAt: time.Now(),
+	})
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	var credits types.RecentCredits
-	err = json.Unmarshal(marshaled, &credits)
-	if err != nil {
-		return nil, err
+
+	msg := mailer.Message{
+		FromName:    "stake-sendgrid-api",
+		FromEmail:   "sgapi@rtradetechnologies.com",
+		ToName:      DefaultRecipientName,
+		ToEmail:     m.recipientFor(opt),
+		Subject:     templateSubjects[templates.TwentyFourHourCredit],
+		ContentType: "text/html",
+		Content:     content,
+		Attachments: []mailer.Attachment{
+			{Filename: chartCID, ContentType: "image/png", Content: chartPNG, ContentID: chartCID},
+		},
 	}
-	return &credits, nil
+	return m.mailerFor(opt).Send(ctx, msg)
 }
 
-// GetRecentCredits is used to get the total number of credits mined over the last 2 week period, broken down into day intervals
-func (m *Manager) GetRecentCredits() (*[]types.RecentCredits, error) {
-	s := "getdashboarddata"
-	m.FormatURL(s)
-	resp, err := http.Get(m.Config.URL)
-	if err != nil {
-		return nil, err
+// firstReportOptions returns opts[0], or the zero ReportOptions when
+// opts is empty. Send24HourEmail and SendTemplateEmail take opts as a
+// trailing variadic argument purely so existing zero-argument call
+// sites keep compiling.
+func firstReportOptions(opts []ReportOptions) ReportOptions {
+	if len(opts) == 0 {
+		return ReportOptions{}
 	}
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	return opts[0]
+}
+
+// sortedCurrencies returns the keys of values sorted alphabetically, so
+// chart axes and table rows render in a deterministic order.
+func sortedCurrencies(values map[string]float64) []string {
+	currencies := make([]string, 0, len(values))
+	for currency := range values {
+		currencies = append(currencies, currency)
 	}
-	var intf map[string]interface{}
-	var data types.MiningPoolHubAPIResponse
-	err = json.Unmarshal(bodyBytes, &intf)
-	if err != nil {
-		return nil, err
+	sort.Strings(currencies)
+	return currencies
+}
+
+// TaxReport is the rendered output of GenerateTaxReport, ready to be
+// attached to an email.
+type TaxReport struct {
+	Daily   []store.Aggregate
+	Monthly []store.Aggregate
+	Yearly  []store.Aggregate
+	CSV     []byte
+	PDF     []byte
+}
+
+// GenerateTaxReport aggregates every sample recorded between from and
+// to into daily/monthly/yearly summaries, and renders the monthly
+// summaries priced in currency as CSV and PDF suitable for attaching to
+// an email. The daily and yearly summaries are returned on TaxReport
+// but are not written to the CSV or PDF. It requires Store to be
+// configured.
+func (m *Manager) GenerateTaxReport(ctx context.Context, from, to time.Time, currency string) (*TaxReport, error) {
+	if m.Store == nil {
+		return nil, fmt.Errorf("reports: GenerateTaxReport requires a configured Store")
 	}
-	marshaled, err := json.Marshal(intf[s])
+	samples, err := m.Store.SamplesBetween(ctx, from, to)
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(marshaled, &data)
-	if err != nil {
-		return nil, err
+
+	report := &TaxReport{
+		Daily:   store.AggregateSamples(samples, store.Daily),
+		Monthly: store.AggregateSamples(samples, store.Monthly),
+		Yearly:  store.AggregateSamples(samples, store.Yearly),
 	}
-	marshaled, err = json.Marshal(data.Data["recent_credits"])
-	if err != nil {
+
+	var csvBuf, pdfBuf bytes.Buffer
+	if err := store.WriteCSV(&csvBuf, report.Monthly, currency); err != nil {
 		return nil, err
 	}
-	var credits []types.RecentCredits
-	err = json.Unmarshal(marshaled, &credits)
-	if err != nil {
+	if err := store.WritePDF(&pdfBuf, report.Monthly, currency); err != nil {
 		return nil, err
 	}
-	return &credits, nil
+	report.CSV = csvBuf.Bytes()
+	report.PDF = pdfBuf.Bytes()
+	return report, nil
 }
 
-// FormatURL is a helper method used to format a URL with the given config information
-func (m *Manager) FormatURL(action string) {
-	m.Config.URL = fmt.Sprintf(m.Config.URL, m.Config.Coin, action, m.Config.APIKey)
-}
+// SendTaxReportEmail generates a tax report for [from, to) priced in
+// currency via GenerateTaxReport and emails it with a monthly totals
+// chart inline and the CSV/PDF attached for bookkeeping.
+func (m *Manager) SendTaxReportEmail(ctx context.Context, from, to time.Time, currency string, opts ...ReportOptions) (int, error) {
+	if m.Templates == nil {
+		return 0, fmt.Errorf("reports: SendTaxReportEmail requires a configured template directory")
+	}
+	opt := firstReportOptions(opts)
 
-// Send24HourEmail is a function used to send report information for the last 24 hour period
-func (m *Manager) Send24HourEmail(ethMined, usdValue, cadValue float64) (int, error) {
-	content := fmt.Sprintf("<br>Eth Mined: %v<br>USD Value: %v<br>CAD Value: %v", ethMined, usdValue, cadValue)
-	from := mail.NewEmail("stake-sendgrid-api", "sgapi@rtradetechnologies.com")
-	subject := "Ethereum Mining Report"
-	to := mail.NewEmail("Mining Reports", "reports@rtradetechnologies.com")
+	report, err := m.GenerateTaxReport(ctx, from, to, currency)
+	if err != nil {
+		return 0, err
+	}
 
-	mContent := mail.NewContent("text/html", content)
-	mail := mail.NewV3MailInit(from, subject, to, mContent)
+	labels := make([]string, len(report.Monthly))
+	chartValues := make([]float64, len(report.Monthly))
+	monthly := make([]templates.MonthlyTotal, len(report.Monthly))
+	for i, agg := range report.Monthly {
+		labels[i] = agg.Period
+		chartValues[i] = agg.TotalFiat[currency]
+		monthly[i] = templates.MonthlyTotal{
+			Period:      agg.Period,
+			Coin:        agg.Coin,
+			TotalAmount: agg.TotalAmount,
+			TotalFiat:   agg.TotalFiat[currency],
+		}
+	}
+	chartPNG, err := templates.RenderBarChartPNG(fmt.Sprintf("Monthly %s Totals", currency), labels, chartValues)
+	if err != nil {
+		return 0, err
+	}
+	const chartCID = "chart.png"
 
-	response, err := m.SendgridClient.Send(mail)
+	content, err := m.Templates.Render(templates.TaxSummary, templates.TaxSummaryContext{
+		Currency: currency,
+		From:     from,
+		To:       to,
+		Monthly:  monthly,
+		ChartCID: chartCID,
+	})
 	if err != nil {
 		return 0, err
 	}
-	return response.StatusCode, nil
-}
 
-// SendTemplateEmail is a function that can be used to send any kind of report email
-func (m *Manager) SendTemplateEmail(args map[string]string) (int, error) {
-	content := args["content"]
-	contentType := args["content_type"]
-	fromName := args["from_name"]
-	fromEmail := args["from_email"]
-	subject := args["subject"]
-	toName := args["to_name"]
-	toEmail := args["to_email"]
+	msg := mailer.Message{
+		FromName:    "stake-sendgrid-api",
+		FromEmail:   "sgapi@rtradetechnologies.com",
+		ToName:      DefaultRecipientName,
+		ToEmail:     m.recipientFor(opt),
+		Subject:     templateSubjects[templates.TaxSummary],
+		ContentType: "text/html",
+		Content:     content,
+		Attachments: []mailer.Attachment{
+			{Filename: chartCID, ContentType: "image/png", Content: chartPNG, ContentID: chartCID},
+			{Filename: fmt.Sprintf("tax-report-%s-%s.csv", from.Format("2006-01-02"), to.Format("2006-01-02")), ContentType: "text/csv", Content: report.CSV},
+			{Filename: fmt.Sprintf("tax-report-%s-%s.pdf", from.Format("2006-01-02"), to.Format("2006-01-02")), ContentType: "application/pdf", Content: report.PDF},
+		},
+	}
+	return m.mailerFor(opt).Send(ctx, msg)
+}
 
-	from := mail.NewEmail(fromName, fromEmail)
-	to := mail.NewEmail(toName, toEmail)
-	mContent := mail.NewContent(contentType, content)
-	mail := mail.NewV3MailInit(from, subject, to, mContent)
-	response, err := m.SendgridClient.Send(mail)
+// SendTemplateEmail renders templateName with data and sends the
+// result as a mining report email.
+func (m *Manager) SendTemplateEmail(ctx context.Context, templateName string, data any, opts ...ReportOptions) (int, error) {
+	if m.Templates == nil {
+		return 0, fmt.Errorf("reports: SendTemplateEmail requires a configured template directory")
+	}
+	opt := firstReportOptions(opts)
+	content, err := m.Templates.Render(templateName, data)
 	if err != nil {
 		return 0, err
 	}
-	return response.StatusCode, nil
+
+	msg := mailer.Message{
+		FromName:    "stake-sendgrid-api",
+		FromEmail:   "sgapi@rtradetechnologies.com",
+		ToName:      DefaultRecipientName,
+		ToEmail:     m.recipientFor(opt),
+		Subject:     templateSubjects[templateName],
+		ContentType: "text/html",
+		Content:     content,
+	}
+	return m.mailerFor(opt).Send(ctx, msg)
 }
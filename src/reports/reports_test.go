@@ -0,0 +1,120 @@
+package reports
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/fx"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/mailer"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/pool"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/store"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/templates"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/types"
+)
+
+type stubPool struct {
+	amount float64
+}
+
+func (p stubPool) RecentCredits24h(ctx context.Context, coin string) (pool.Credits, error) {
+	return types.RecentCredits{Amount: p.amount, Timestamp: 1000}, nil
+}
+
+func (p stubPool) RecentCreditsHistory(ctx context.Context, coin string) ([]pool.Credits, error) {
+	return []pool.Credits{{Amount: p.amount, Timestamp: 1000}}, nil
+}
+
+func TestCreateReportAndSendDeliversThroughMockMailer(t *testing.T) {
+	renderer, err := templates.New(filepath.Join("templates", "html"))
+	if err != nil {
+		t.Fatalf("templates.New returned error: %v", err)
+	}
+	m := mailer.NewMockMailer()
+	manager := &Manager{
+		Config:    &config.Config{Coin: "ETH", TargetCurrencies: []string{"USD"}},
+		Pool:      stubPool{amount: 1.5},
+		FX:        fx.NewFixedProvider(map[string]float64{"ETH_USD": 1800}),
+		Mailer:    m,
+		Templates: renderer,
+	}
+
+	if err := manager.CreateReportAndSend(context.Background(), "24hour_credit"); err != nil {
+		t.Fatalf("CreateReportAndSend returned error: %v", err)
+	}
+
+	if len(m.Sent) != 1 {
+		t.Fatalf("expected 1 email sent, got %d", len(m.Sent))
+	}
+	sent := m.Sent[0]
+	if sent.Subject != "Ethereum Mining Report" {
+		t.Fatalf("expected subject %q, got %q", "Ethereum Mining Report", sent.Subject)
+	}
+	if !strings.Contains(sent.Content, "USD Value") {
+		t.Fatalf("expected rendered body to include the USD conversion, got: %s", sent.Content)
+	}
+	if len(sent.Attachments) != 1 {
+		t.Fatalf("expected 1 inline chart attachment, got %d", len(sent.Attachments))
+	}
+}
+
+func TestSendTaxReportEmailRendersAndAttachesReport(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reports.db")
+	db, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("store.Open returned error: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)
+	if err := db.RecordSample(ctx, store.Sample{
+		Timestamp:  from.Add(24 * time.Hour),
+		Coin:       "ETH",
+		Pool:       "ethermine",
+		Amount:     1.5,
+		FXRates:    map[string]float64{"USD": 1800},
+		FiatValues: map[string]float64{"USD": 2700},
+	}); err != nil {
+		t.Fatalf("RecordSample returned error: %v", err)
+	}
+
+	renderer, err := templates.New(filepath.Join("templates", "html"))
+	if err != nil {
+		t.Fatalf("templates.New returned error: %v", err)
+	}
+	m := mailer.NewMockMailer()
+	manager := &Manager{
+		Config:    &config.Config{Coin: "ETH", TargetCurrencies: []string{"USD"}},
+		FX:        fx.NewFixedProvider(map[string]float64{"ETH_USD": 1800}),
+		Mailer:    m,
+		Store:     db,
+		Templates: renderer,
+	}
+
+	status, err := manager.SendTaxReportEmail(ctx, from, to, "USD")
+	if err != nil {
+		t.Fatalf("SendTaxReportEmail returned error: %v", err)
+	}
+	if status != 202 {
+		t.Fatalf("expected status 202, got %v", status)
+	}
+
+	if len(m.Sent) != 1 {
+		t.Fatalf("expected 1 email sent, got %d", len(m.Sent))
+	}
+	sent := m.Sent[0]
+	if sent.Subject != "Mining Tax Summary" {
+		t.Fatalf("expected subject %q, got %q", "Mining Tax Summary", sent.Subject)
+	}
+	if !strings.Contains(sent.Content, "2026-07") {
+		t.Fatalf("expected rendered body to mention the aggregated period, got: %s", sent.Content)
+	}
+	if len(sent.Attachments) != 3 {
+		t.Fatalf("expected chart + CSV + PDF attachments, got %d", len(sent.Attachments))
+	}
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+import "time"
+
+// backoffBase and backoffMax bound the exponential backoff applied
+// between retries of a job with consecutive failures, so a flaky
+// pool/FX/mail provider doesn't get hammered on every cron tick.
+const (
+	backoffBase = 30 * time.Second
+	backoffMax  = 30 * time.Minute
+)
+
+// nextBackoff returns how long a job should wait since LastRunAt
+// before it's retried, given fails consecutive failures. It doubles
+// from backoffBase and caps at backoffMax.
+func nextBackoff(fails int) time.Duration {
+	if fails <= 0 {
+		return 0
+	}
+	d := backoffBase
+	for i := 1; i < fails; i++ {
+		d *= 2
+		if d >= backoffMax {
+			return backoffMax
+		}
+	}
+	return d
+}
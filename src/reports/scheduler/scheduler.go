@@ -0,0 +1,230 @@
+// Package scheduler drives a Manager's recurring report jobs on their
+// own cron schedules, instead of leaving callers to invoke
+// reports.Manager.CreateReportAndSend from an external cron entry.
+// Each job gets exponential backoff on failure, a circuit breaker that
+// falls back to a secondary Mailer after repeated failures, and an
+// alert when its pool goes quiet.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/mailer"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/templates"
+)
+
+// DefaultFailureThreshold is the number of consecutive failures a job
+// tolerates before its circuit breaker trips, used when
+// config.Config.SchedulerFailureThreshold is <= 0.
+const DefaultFailureThreshold = 5
+
+// silentCreditMethod is the only report method that produces a credit
+// amount to watch for silence, so it's the only one checkSilence acts
+// on.
+const silentCreditMethod = "24hour_credit"
+
+// Scheduler drives Manager.Config.Jobs via robfig/cron/v3.
+type Scheduler struct {
+	manager   *reports.Manager
+	fallback  mailer.Mailer
+	jobs      []config.SchedulerJob
+	threshold int
+	state     *stateStore
+
+	cron *cron.Cron
+}
+
+// New constructs a Scheduler driving manager.Config.Jobs. It returns
+// an error if manager.Config has no jobs configured, if any job's
+// CronExpr is invalid, or if the configured FallbackMailProvider can't
+// be constructed.
+func New(manager *reports.Manager) (*Scheduler, error) {
+	cfg := manager.Config
+	if len(cfg.Jobs) == 0 {
+		return nil, fmt.Errorf("scheduler: config has no jobs configured")
+	}
+
+	fallbackCfg := *cfg
+	fallbackCfg.MailProvider = cfg.FallbackMailProvider
+	if fallbackCfg.MailProvider == "" {
+		fallbackCfg.MailProvider = mailer.ProviderNull
+	}
+	fallback, err := mailer.New(&fallbackCfg)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: constructing fallback mailer: %w", err)
+	}
+
+	threshold := cfg.SchedulerFailureThreshold
+	if threshold <= 0 {
+		threshold = DefaultFailureThreshold
+	}
+
+	state, err := loadState(cfg.SchedulerStatePath)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: loading state from %q: %w", cfg.SchedulerStatePath, err)
+	}
+
+	s := &Scheduler{
+		manager:   manager,
+		fallback:  fallback,
+		jobs:      cfg.Jobs,
+		threshold: threshold,
+		state:     state,
+		cron:      cron.New(),
+	}
+
+	for i, job := range cfg.Jobs {
+		key := jobKey(job, i)
+		job := job
+		if _, err := s.cron.AddFunc(job.CronExpr, func() {
+			s.runJob(context.Background(), key, job)
+		}); err != nil {
+			return nil, fmt.Errorf("scheduler: job %q: invalid cron expression %q: %w", key, job.CronExpr, err)
+		}
+	}
+
+	return s, nil
+}
+
+// Start begins running every configured job on its cron schedule in
+// the background. Call Stop to shut the scheduler down.
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop halts the underlying cron scheduler and waits for any in-flight
+// job to finish.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+// jobKey identifies a job for state-tracking purposes. Jobs are keyed
+// by their position in Config.Jobs rather than just Method, so two
+// jobs running the same method on different schedules don't share
+// backoff or circuit-breaker state.
+func jobKey(job config.SchedulerJob, index int) string {
+	return fmt.Sprintf("%d:%s", index, job.Method)
+}
+
+// runJob sends job's report, applying backoff after failures, tripping
+// the circuit breaker to s.fallback after s.threshold consecutive
+// failures, and checking for farm silence once the send completes.
+func (s *Scheduler) runJob(ctx context.Context, key string, job config.SchedulerJob) {
+	js := s.state.get(key)
+
+	if js.ConsecutiveFails > 0 {
+		if wait := nextBackoff(js.ConsecutiveFails); time.Since(js.LastRunAt) < wait {
+			log.Printf("scheduler: job %q: skipping run, backing off %s after %d consecutive failures", key, wait, js.ConsecutiveFails)
+			return
+		}
+	}
+	js.LastRunAt = time.Now()
+
+	opts := reports.ReportOptions{Currencies: job.Currencies}
+	if len(job.Recipients) > 0 {
+		opts.Recipient = job.Recipients[0]
+	}
+	if js.BreakerTripped {
+		opts.Mailer = s.fallback
+	}
+
+	if err := s.manager.CreateReportAndSendWithOptions(ctx, job.Method, opts); err != nil {
+		js.ConsecutiveFails++
+		if !js.BreakerTripped && js.ConsecutiveFails >= s.threshold {
+			js.BreakerTripped = true
+			log.Printf("scheduler: job %q: circuit breaker tripped after %d consecutive failures, falling back to secondary mailer", key, js.ConsecutiveFails)
+		}
+		log.Printf("scheduler: job %q: send failed (%d consecutive): %v", key, js.ConsecutiveFails, err)
+		if serr := s.state.set(key, js); serr != nil {
+			log.Printf("scheduler: job %q: failed to persist state: %v", key, serr)
+		}
+		s.checkSilence(ctx, job, key, err)
+		return
+	}
+
+	js.ConsecutiveFails = 0
+	js.BreakerTripped = false
+	if serr := s.state.set(key, js); serr != nil {
+		log.Printf("scheduler: job %q: failed to persist state: %v", key, serr)
+	}
+
+	s.checkSilence(ctx, job, key, nil)
+}
+
+// checkSilence alerts when job's pool has stopped reporting credits,
+// either because the last send failed (sendErr != nil, eg the pool API
+// stopped responding) or because RecentCredits24h most recently
+// returned zero. It sends at most one alert per continuous silence
+// episode, resetting once credits resume.
+func (s *Scheduler) checkSilence(ctx context.Context, job config.SchedulerJob, key string, sendErr error) {
+	if job.Method != silentCreditMethod {
+		return
+	}
+	js := s.state.get(key)
+
+	credits, err := s.manager.GetRecentCredits24Hours(ctx)
+	reason := ""
+	switch {
+	case sendErr != nil:
+		reason = fmt.Sprintf("pool API stopped responding: %v", sendErr)
+	case err != nil:
+		reason = fmt.Sprintf("pool API stopped responding: %v", err)
+	case credits.Amount == 0:
+		reason = "pool reported zero credits for the last 24 hours"
+	}
+
+	if reason == "" {
+		// Update on every healthy run, not just the first one or the
+		// one right after a recovered alert, so a later silence
+		// episode measures actual downtime instead of total uptime
+		// since the farm's very first healthy tick.
+		js.LastNonZeroCreditAt = time.Now()
+		js.AlertSent = false
+		if serr := s.state.set(key, js); serr != nil {
+			log.Printf("scheduler: job %q: failed to persist state: %v", key, serr)
+		}
+		return
+	}
+
+	if js.LastNonZeroCreditAt.IsZero() {
+		js.LastNonZeroCreditAt = time.Now()
+	}
+	if js.AlertSent {
+		return
+	}
+
+	hours := time.Since(js.LastNonZeroCreditAt).Hours()
+	if err := s.sendSilentAlert(ctx, job, hours, reason); err != nil {
+		log.Printf("scheduler: job %q: failed to send silence alert: %v", key, err)
+		return
+	}
+	js.AlertSent = true
+	if serr := s.state.set(key, js); serr != nil {
+		log.Printf("scheduler: job %q: failed to persist state: %v", key, serr)
+	}
+}
+
+// sendSilentAlert renders and sends the "mining farm silent" email for
+// job.
+func (s *Scheduler) sendSilentAlert(ctx context.Context, job config.SchedulerJob, hours float64, reason string) error {
+	opts := reports.ReportOptions{}
+	if len(job.Recipients) > 0 {
+		opts.Recipient = job.Recipients[0]
+	}
+
+	_, err := s.manager.SendTemplateEmail(ctx, templates.SilentAlert, templates.SilentAlertContext{
+		PoolName:    s.manager.PoolName(),
+		Coin:        s.manager.Config.Coin,
+		HoursSilent: hours,
+		Reason:      reason,
+		GeneratedAt: time.Now(),
+	}, opts)
+	return err
+}
@@ -0,0 +1,225 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RTradeLtd/mining-bootstrap/src/reports"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/config"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/fx"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/mailer"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/pool"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/templates"
+	"github.com/RTradeLtd/mining-bootstrap/src/reports/types"
+)
+
+func TestNextBackoffDoublesAndCaps(t *testing.T) {
+	cases := []struct {
+		fails int
+		want  time.Duration
+	}{
+		{0, 0},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{20, backoffMax},
+	}
+	for _, c := range cases {
+		if got := nextBackoff(c.fails); got != c.want {
+			t.Errorf("nextBackoff(%d) = %v, want %v", c.fails, got, c.want)
+		}
+	}
+}
+
+func TestStateStorePersistsAcrossLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler_state.json")
+
+	s, err := loadState(path)
+	if err != nil {
+		t.Fatalf("loadState returned error: %v", err)
+	}
+	want := jobState{ConsecutiveFails: 3, BreakerTripped: true}
+	if err := s.set("0:24hour_credit", want); err != nil {
+		t.Fatalf("set returned error: %v", err)
+	}
+
+	reloaded, err := loadState(path)
+	if err != nil {
+		t.Fatalf("loadState returned error on reload: %v", err)
+	}
+	if got := reloaded.get("0:24hour_credit"); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+// erroringPool always fails RecentCredits24h, simulating a pool API
+// that's stopped responding.
+type erroringPool struct{}
+
+func (erroringPool) RecentCredits24h(ctx context.Context, coin string) (pool.Credits, error) {
+	return types.RecentCredits{}, errors.New("pool unreachable")
+}
+
+func (erroringPool) RecentCreditsHistory(ctx context.Context, coin string) ([]pool.Credits, error) {
+	return nil, errors.New("pool unreachable")
+}
+
+func newTestManager(p pool.Client, m mailer.Mailer) *reports.Manager {
+	renderer, err := templates.New(filepath.Join("..", "templates", "html"))
+	if err != nil {
+		panic(err)
+	}
+	return &reports.Manager{
+		Config:    &config.Config{Coin: "ETH", TargetCurrencies: []string{"USD"}},
+		Pool:      p,
+		FX:        fx.NewFixedProvider(map[string]float64{"ETH_USD": 1800}),
+		Mailer:    m,
+		Templates: renderer,
+	}
+}
+
+func TestRunJobTripsCircuitBreakerAfterThreshold(t *testing.T) {
+	manager := newTestManager(erroringPool{}, mailer.NewMockMailer())
+	state, err := loadState("")
+	if err != nil {
+		t.Fatalf("loadState returned error: %v", err)
+	}
+	s := &Scheduler{manager: manager, fallback: mailer.NewMockMailer(), threshold: 2, state: state}
+
+	job := config.SchedulerJob{Method: "24hour_credit"}
+	key := "0:24hour_credit"
+
+	// Seed one prior failure, far enough in the past that backoff
+	// doesn't suppress this run.
+	state.set(key, jobState{ConsecutiveFails: 1, LastRunAt: time.Now().Add(-time.Hour)})
+
+	s.runJob(context.Background(), key, job)
+
+	got := state.get(key)
+	if got.ConsecutiveFails != 2 {
+		t.Fatalf("expected 2 consecutive fails, got %d", got.ConsecutiveFails)
+	}
+	if !got.BreakerTripped {
+		t.Fatalf("expected circuit breaker to trip after reaching threshold")
+	}
+}
+
+func TestRunJobUsesFallbackMailerOnceBreakerTripped(t *testing.T) {
+	primary := mailer.NewMockMailer()
+	fallback := mailer.NewMockMailer()
+	manager := newTestManager(&toggleablePool{amount: 1.5}, primary)
+	state, err := loadState("")
+	if err != nil {
+		t.Fatalf("loadState returned error: %v", err)
+	}
+	s := &Scheduler{manager: manager, fallback: fallback, threshold: 2, state: state}
+
+	job := config.SchedulerJob{Method: "24hour_credit"}
+	key := "0:24hour_credit"
+	state.set(key, jobState{ConsecutiveFails: 2, BreakerTripped: true, LastRunAt: time.Now().Add(-time.Hour)})
+
+	s.runJob(context.Background(), key, job)
+
+	if len(primary.Sent) != 0 {
+		t.Fatalf("expected primary mailer to be bypassed, got %d sends", len(primary.Sent))
+	}
+	if len(fallback.Sent) != 1 {
+		t.Fatalf("expected 1 send through the fallback mailer, got %d", len(fallback.Sent))
+	}
+}
+
+func TestCheckSilenceAlertsOnceThenResetsAfterRecovery(t *testing.T) {
+	p := &toggleablePool{}
+	manager := newTestManager(p, mailer.NewMockMailer())
+	state, err := loadState("")
+	if err != nil {
+		t.Fatalf("loadState returned error: %v", err)
+	}
+	s := &Scheduler{manager: manager, fallback: mailer.NewMockMailer(), threshold: DefaultFailureThreshold, state: state}
+
+	job := config.SchedulerJob{Method: "24hour_credit"}
+	key := "0:24hour_credit"
+
+	const alertSubject = "Mining Farm Silent Alert"
+	countAlerts := func() int {
+		n := 0
+		for _, msg := range manager.Mailer.(*mailer.MockMailer).Sent {
+			if msg.Subject == alertSubject {
+				n++
+			}
+		}
+		return n
+	}
+
+	p.amount = 0
+	s.runJob(context.Background(), key, job)
+	if !state.get(key).AlertSent {
+		t.Fatalf("expected alert to be sent on first silent run")
+	}
+	if got := countAlerts(); got != 1 {
+		t.Fatalf("expected 1 alert email after the first silent run, got %d", got)
+	}
+
+	// A second consecutive silent run shouldn't alert again.
+	s.runJob(context.Background(), key, job)
+	if got := countAlerts(); got != 1 {
+		t.Fatalf("expected no additional alert email on a repeat silent run, got %d", got)
+	}
+
+	// Recovery clears AlertSent so a later silent spell alerts again.
+	p.amount = 1.5
+	s.runJob(context.Background(), key, job)
+	if state.get(key).AlertSent {
+		t.Fatalf("expected AlertSent to reset once credits resumed")
+	}
+}
+
+func TestCheckSilenceMeasuresActualDowntimeNotTotalUptime(t *testing.T) {
+	p := &toggleablePool{amount: 1.5}
+	manager := newTestManager(p, mailer.NewMockMailer())
+	state, err := loadState("")
+	if err != nil {
+		t.Fatalf("loadState returned error: %v", err)
+	}
+	s := &Scheduler{manager: manager, fallback: mailer.NewMockMailer(), threshold: DefaultFailureThreshold, state: state}
+
+	job := config.SchedulerJob{Method: "24hour_credit"}
+	key := "0:24hour_credit"
+
+	// Seed a long-healthy farm: its first-ever healthy tick was 100
+	// hours ago, and it's kept reporting credits since.
+	state.set(key, jobState{LastNonZeroCreditAt: time.Now().Add(-100 * time.Hour)})
+	s.checkSilence(context.Background(), job, key, nil)
+
+	// Now it goes silent. The alert should report downtime since this
+	// last healthy tick (effectively 0h), not since the 100-hour-old
+	// first tick.
+	p.amount = 0
+	s.checkSilence(context.Background(), job, key, nil)
+
+	sent := manager.Mailer.(*mailer.MockMailer).Sent
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 alert email, got %d", len(sent))
+	}
+	if got := sent[0].Content; !strings.Contains(got, "0.0 hours") {
+		t.Fatalf("expected alert to report ~0 hours silent, got content: %s", got)
+	}
+}
+
+// toggleablePool lets a test flip between reporting credits and
+// reporting silence.
+type toggleablePool struct {
+	amount float64
+}
+
+func (p *toggleablePool) RecentCredits24h(ctx context.Context, coin string) (pool.Credits, error) {
+	return types.RecentCredits{Amount: p.amount, Timestamp: 1000}, nil
+}
+
+func (p *toggleablePool) RecentCreditsHistory(ctx context.Context, coin string) ([]pool.Credits, error) {
+	return []pool.Credits{{Amount: p.amount, Timestamp: 1000}}, nil
+}
@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// jobState is a single job's last-run bookkeeping, persisted to the
+// state file so a scheduler restart doesn't double-send a report or
+// re-alert on a silence episode it already handled.
+type jobState struct {
+	// LastRunAt is when this job was last attempted, used to enforce
+	// backoff between retries after a failure.
+	LastRunAt time.Time `json:"last_run_at"`
+	// ConsecutiveFails counts failed sends since the last success.
+	ConsecutiveFails int `json:"consecutive_fails"`
+	// BreakerTripped is set once ConsecutiveFails reaches the
+	// scheduler's failure threshold, switching this job's sends to
+	// the fallback Mailer until one succeeds.
+	BreakerTripped bool `json:"breaker_tripped"`
+	// LastNonZeroCreditAt is when RecentCredits24h last reported a
+	// non-zero amount, the basis for a silence alert's "X hours"
+	// figure.
+	LastNonZeroCreditAt time.Time `json:"last_non_zero_credit_at"`
+	// AlertSent is set once a silence alert has gone out for the
+	// current silence episode, so it isn't repeated on every tick
+	// until the farm recovers.
+	AlertSent bool `json:"alert_sent"`
+}
+
+// persistedState is the on-disk shape of a scheduler's state file,
+// keyed by jobKey.
+type persistedState struct {
+	Jobs map[string]jobState `json:"jobs"`
+}
+
+// stateStore persists jobState to a JSON file on disk so it survives a
+// scheduler restart.
+type stateStore struct {
+	path string
+
+	mu   sync.Mutex
+	data persistedState
+}
+
+// loadState reads the state file at path, returning an empty,
+// never-persisted store when path is empty or the file doesn't yet
+// exist.
+func loadState(path string) (*stateStore, error) {
+	s := &stateStore{path: path, data: persistedState{Jobs: make(map[string]jobState)}}
+	if path == "" {
+		return s, nil
+	}
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &s.data); err != nil {
+		return nil, err
+	}
+	if s.data.Jobs == nil {
+		s.data.Jobs = make(map[string]jobState)
+	}
+	return s, nil
+}
+
+// get returns the persisted state for key, or its zero value when
+// nothing has been recorded yet.
+func (s *stateStore) get(key string) jobState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.Jobs[key]
+}
+
+// set persists js for key, writing the whole state file when s.path is
+// non-empty.
+func (s *stateStore) set(key string, js jobState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Jobs[key] = js
+	if s.path == "" {
+		return nil
+	}
+	raw, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, raw, 0o644)
+}
@@ -0,0 +1,64 @@
+package store
+
+// Aggregate sums every Sample falling into the same Period, eg all
+// samples from "2026-07-26" for a daily aggregate.
+type Aggregate struct {
+	Period      string
+	Coin        string
+	TotalAmount float64
+	TotalFiat   map[string]float64
+}
+
+// Granularity selects how samples are bucketed when aggregating.
+type Granularity int
+
+// Supported Granularity values.
+const (
+	Daily Granularity = iota
+	Monthly
+	Yearly
+)
+
+// periodLayout is the time.Format layout used to bucket a sample's
+// timestamp for the given granularity.
+func (g Granularity) periodLayout() string {
+	switch g {
+	case Monthly:
+		return "2006-01"
+	case Yearly:
+		return "2006"
+	default:
+		return "2006-01-02"
+	}
+}
+
+// AggregateSamples buckets samples by granularity, summing Amount and every
+// currency in FiatValues per bucket. Samples for different coins are
+// kept in separate buckets even within the same period.
+func AggregateSamples(samples []Sample, granularity Granularity) []Aggregate {
+	layout := granularity.periodLayout()
+
+	order := make([]string, 0)
+	byKey := make(map[string]*Aggregate)
+	for _, sample := range samples {
+		period := sample.Timestamp.UTC().Format(layout)
+		key := period + "|" + sample.Coin
+
+		agg, ok := byKey[key]
+		if !ok {
+			agg = &Aggregate{Period: period, Coin: sample.Coin, TotalFiat: make(map[string]float64)}
+			byKey[key] = agg
+			order = append(order, key)
+		}
+		agg.TotalAmount += sample.Amount
+		for currency, value := range sample.FiatValues {
+			agg.TotalFiat[currency] += value
+		}
+	}
+
+	aggregates := make([]Aggregate, len(order))
+	for i, key := range order {
+		aggregates[i] = *byKey[key]
+	}
+	return aggregates
+}
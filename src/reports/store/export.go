@@ -0,0 +1,61 @@
+package store
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// WriteCSV renders aggregates as a CSV suitable for attaching to a tax
+// report email, with one row per period and amount/value columns for
+// currency.
+func WriteCSV(w io.Writer, aggregates []Aggregate, currency string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"period", "coin", "amount", currency}); err != nil {
+		return err
+	}
+	for _, agg := range aggregates {
+		row := []string{
+			agg.Period,
+			agg.Coin,
+			fmt.Sprintf("%v", agg.TotalAmount),
+			fmt.Sprintf("%v", agg.TotalFiat[currency]),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WritePDF renders aggregates as a simple tabular PDF suitable for
+// attaching to a tax report email.
+func WritePDF(w io.Writer, aggregates []Aggregate, currency string) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 10, "Mining Tax Report", "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Arial", "B", 11)
+	colWidths := []float64{40, 30, 40, 40}
+	headers := []string{"Period", "Coin", "Amount", currency}
+	for i, h := range headers {
+		pdf.CellFormat(colWidths[i], 8, h, "1", 0, "L", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Arial", "", 11)
+	for _, agg := range aggregates {
+		pdf.CellFormat(colWidths[0], 8, agg.Period, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[1], 8, agg.Coin, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[2], 8, fmt.Sprintf("%v", agg.TotalAmount), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[3], 8, fmt.Sprintf("%v", agg.TotalFiat[currency]), "1", 0, "L", false, 0, "")
+		pdf.Ln(-1)
+	}
+
+	return pdf.Output(w)
+}
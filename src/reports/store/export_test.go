@@ -0,0 +1,26 @@
+package store
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	aggregates := []Aggregate{
+		{Period: "2026-07", Coin: "ETH", TotalAmount: 6, TotalFiat: map[string]float64{"USD": 10800}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, aggregates, "USD"); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "period,coin,amount,USD") {
+		t.Fatalf("expected header row, got %q", out)
+	}
+	if !strings.Contains(out, "2026-07,ETH,6,10800") {
+		t.Fatalf("expected data row, got %q", out)
+	}
+}
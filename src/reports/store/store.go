@@ -0,0 +1,116 @@
+// Package store persists fetched credit samples to SQLite so historical
+// reports can be aggregated later, instead of every run emailing a
+// number and forgetting it.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS samples (
+	id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp          INTEGER NOT NULL,
+	coin               TEXT NOT NULL,
+	pool               TEXT NOT NULL,
+	amount             REAL NOT NULL,
+	fx_rates_snapshot  TEXT NOT NULL,
+	fiat_values        TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_samples_timestamp ON samples(timestamp);
+`
+
+// Sample is a single fetched credit reading, along with the fiat
+// conversion snapshot taken at fetch time.
+type Sample struct {
+	ID        int64
+	Timestamp time.Time
+	Coin      string
+	Pool      string
+	Amount    float64
+	// FXRates is the "currency" -> rate snapshot used to compute
+	// FiatValues, so historical reports remain accurate even after
+	// rates move on.
+	FXRates    map[string]float64
+	FiatValues map[string]float64
+}
+
+// Store persists Samples to a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and
+// ensures its schema is up to date.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// RecordSample persists sample.
+func (s *Store) RecordSample(ctx context.Context, sample Sample) error {
+	rates, err := json.Marshal(sample.FXRates)
+	if err != nil {
+		return err
+	}
+	fiat, err := json.Marshal(sample.FiatValues)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO samples (timestamp, coin, pool, amount, fx_rates_snapshot, fiat_values) VALUES (?, ?, ?, ?, ?, ?)`,
+		sample.Timestamp.Unix(), sample.Coin, sample.Pool, sample.Amount, string(rates), string(fiat),
+	)
+	return err
+}
+
+// SamplesBetween returns every sample recorded with a timestamp in
+// [from, to), ordered oldest first.
+func (s *Store) SamplesBetween(ctx context.Context, from, to time.Time) ([]Sample, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, timestamp, coin, pool, amount, fx_rates_snapshot, fiat_values FROM samples WHERE timestamp >= ? AND timestamp < ? ORDER BY timestamp ASC`,
+		from.Unix(), to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []Sample
+	for rows.Next() {
+		var (
+			sample              Sample
+			ts                  int64
+			ratesJSON, fiatJSON string
+		)
+		if err := rows.Scan(&sample.ID, &ts, &sample.Coin, &sample.Pool, &sample.Amount, &ratesJSON, &fiatJSON); err != nil {
+			return nil, err
+		}
+		sample.Timestamp = time.Unix(ts, 0).UTC()
+		if err := json.Unmarshal([]byte(ratesJSON), &sample.FXRates); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(fiatJSON), &sample.FiatValues); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, rows.Err()
+}
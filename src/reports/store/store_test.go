@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordAndAggregateSamples(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reports.db")
+	s, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer s.Close()
+
+	day1 := time.Date(2026, 7, 26, 10, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 7, 27, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	samples := []Sample{
+		{Timestamp: day1, Coin: "ETH", Pool: "ethermine", Amount: 1, FXRates: map[string]float64{"USD": 1800}, FiatValues: map[string]float64{"USD": 1800}},
+		{Timestamp: day1, Coin: "ETH", Pool: "ethermine", Amount: 2, FXRates: map[string]float64{"USD": 1800}, FiatValues: map[string]float64{"USD": 3600}},
+		{Timestamp: day2, Coin: "ETH", Pool: "ethermine", Amount: 3, FXRates: map[string]float64{"USD": 1900}, FiatValues: map[string]float64{"USD": 5700}},
+	}
+	for _, sample := range samples {
+		if err := s.RecordSample(ctx, sample); err != nil {
+			t.Fatalf("RecordSample returned error: %v", err)
+		}
+	}
+
+	got, err := s.SamplesBetween(ctx, day1, day2.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("SamplesBetween returned error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 samples, got %v", len(got))
+	}
+
+	daily := AggregateSamples(got, Daily)
+	if len(daily) != 2 {
+		t.Fatalf("expected 2 daily aggregates, got %v", len(daily))
+	}
+	if daily[0].Period != "2026-07-26" || daily[0].TotalAmount != 3 || daily[0].TotalFiat["USD"] != 5400 {
+		t.Fatalf("unexpected first daily aggregate: %+v", daily[0])
+	}
+	if daily[1].Period != "2026-07-27" || daily[1].TotalAmount != 3 || daily[1].TotalFiat["USD"] != 5700 {
+		t.Fatalf("unexpected second daily aggregate: %+v", daily[1])
+	}
+
+	monthly := AggregateSamples(got, Monthly)
+	if len(monthly) != 1 || monthly[0].Period != "2026-07" || monthly[0].TotalAmount != 6 {
+		t.Fatalf("unexpected monthly aggregate: %+v", monthly)
+	}
+}
@@ -0,0 +1,34 @@
+package templates
+
+import (
+	"bytes"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// RenderBarChartPNG renders a simple bar chart of values (in the order
+// given by labels) as a PNG, for embedding as an inline CID attachment
+// on a report email.
+func RenderBarChartPNG(title string, labels []string, values []float64) ([]byte, error) {
+	p := plot.New()
+	p.Title.Text = title
+
+	bars, err := plotter.NewBarChart(plotter.Values(values), vg.Points(20))
+	if err != nil {
+		return nil, err
+	}
+	p.Add(bars)
+	p.NominalX(labels...)
+
+	writer, err := p.WriterTo(4*vg.Inch, 3*vg.Inch, "png")
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if _, err := writer.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
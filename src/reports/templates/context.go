@@ -0,0 +1,52 @@
+package templates
+
+import "time"
+
+// TwentyFourHourContext is the render context for the
+// TwentyFourHourCredit template.
+type TwentyFourHourContext struct {
+	PoolName    string
+	Coin        string
+	AmountMined float64
+	// FXSnapshot is the "currency" -> rate used to compute
+	// Conversions, included so a report is reproducible later.
+	FXSnapshot map[string]float64
+	// Conversions is the "currency" -> fiat value of AmountMined.
+	Conversions map[string]float64
+	// ChartCID is the Content-ID of the inline chart image attached
+	// to the email, referenced from the template as
+	// <img src="cid:{{.ChartCID}}">.
+	ChartCID    string
+	GeneratedAt time.Time
+}
+
+// TaxSummaryContext is the render context for the TaxSummary template.
+type TaxSummaryContext struct {
+	Currency string
+	From     time.Time
+	To       time.Time
+	Monthly  []MonthlyTotal
+	ChartCID string
+}
+
+// MonthlyTotal is one row of a tax summary's monthly breakdown.
+type MonthlyTotal struct {
+	Period      string
+	Coin        string
+	TotalAmount float64
+	TotalFiat   float64
+}
+
+// SilentAlertContext is the render context for the SilentAlert
+// template.
+type SilentAlertContext struct {
+	PoolName string
+	Coin     string
+	// HoursSilent is how long it's been since the pool last reported
+	// a non-zero credit, or since the alert's trigger was first seen.
+	HoursSilent float64
+	// Reason describes what tripped the alert, eg "pool reported zero
+	// credits" or a pool API error.
+	Reason      string
+	GeneratedAt time.Time
+}
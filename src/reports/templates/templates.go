@@ -0,0 +1,45 @@
+// Package templates renders report emails from html/template files on
+// disk, instead of building bodies with fmt.Sprintf.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path/filepath"
+)
+
+// Names of the templates each report method renders.
+const (
+	TwentyFourHourCredit = "24hour_credit"
+	Credit               = "credit"
+	TaxSummary           = "tax_summary"
+	// SilentAlert is rendered by reports/scheduler when a farm's pool
+	// stops reporting credits.
+	SilentAlert = "silent_alert"
+)
+
+// Renderer renders named report templates loaded from disk.
+type Renderer struct {
+	templates *template.Template
+}
+
+// New parses every *.html file in dir into a Renderer. dir is
+// typically config.Config.TemplateDir.
+func New(dir string) (*Renderer, error) {
+	tmpl, err := template.ParseGlob(filepath.Join(dir, "*.html"))
+	if err != nil {
+		return nil, fmt.Errorf("templates: %w", err)
+	}
+	return &Renderer{templates: tmpl}, nil
+}
+
+// Render executes the template named name with data and returns the
+// resulting HTML.
+func (r *Renderer) Render(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := r.templates.ExecuteTemplate(&buf, name+".html", data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,43 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRenderTwentyFourHourCredit(t *testing.T) {
+	r, err := New("html")
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	got, err := r.Render(TwentyFourHourCredit, TwentyFourHourContext{
+		PoolName:    "ethermine",
+		Coin:        "ETH",
+		AmountMined: 1.5,
+		FXSnapshot:  map[string]float64{"CAD": 2400, "USD": 1800},
+		Conversions: map[string]float64{"CAD": 3600, "USD": 2700},
+		ChartCID:    "chart.png",
+		GeneratedAt: time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "24hour_credit.golden.html")
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if got != string(want) {
+		t.Fatalf("rendered HTML does not match golden file:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,9 @@
+package types
+
+// RecentCredits holds the amount of a coin credited to a pool account
+// over some reporting interval, along with the timestamp the pool
+// attaches to that interval.
+type RecentCredits struct {
+	Amount    float64 `json:"amount"`
+	Timestamp int64   `json:"timestamp"`
+}